@@ -0,0 +1,49 @@
+package kpopnet
+
+import (
+	"time"
+)
+
+// Scope is a permission granted to API key.
+type Scope string
+
+const (
+	// ScopeRead allows to read profiles and job results.
+	ScopeRead Scope = "read"
+	// ScopeRecognize allows to recognize and verify faces.
+	ScopeRecognize Scope = "recognize"
+	// ScopeAdmin allows to use admin API. It implies all other scopes.
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes lists all known scopes.
+var Scopes = []Scope{ScopeRead, ScopeRecognize, ScopeAdmin}
+
+// APIKey describes client of the API. The key itself is known only to
+// the client, just its hash is stored.
+type APIKey struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Scopes    []Scope   `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HasScope checks whether key grants the given scope.
+func (k *APIKey) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidScope checks that scope is known.
+func ValidScope(scope Scope) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -2,20 +2,37 @@ package cache
 
 import (
 	"sync"
+
+	"github.com/kpopnet/go-kpopnet/metrics"
 )
 
 type cacheKey int
 
 const (
-	// ProfileCacheKey is a key for caching profiles info.
+	// ProfileCacheKey is a key for caching encoded profiles info.
 	ProfileCacheKey cacheKey = iota
 	// TrainDataCacheKey is a key for caching train data.
 	TrainDataCacheKey
+	// SearchIndexCacheKey is a key for caching profiles search index.
+	SearchIndexCacheKey
+	// ProfileDataCacheKey is a key for caching decoded profiles info.
+	ProfileDataCacheKey
 )
 
+var keyNames = map[cacheKey]string{
+	ProfileCacheKey:     "profile",
+	TrainDataCacheKey:   "train_data",
+	SearchIndexCacheKey: "search_index",
+	ProfileDataCacheKey: "profile_data",
+}
+
+func (key cacheKey) String() string {
+	return keyNames[key]
+}
+
 var (
 	mu    sync.Mutex
-	cache = make(map[cacheKey]interface{}, 2)
+	cache = make(map[cacheKey]interface{}, 4)
 )
 
 // Cached either returns data from cache or makes it via provided callback.
@@ -25,8 +42,10 @@ func Cached(key cacheKey, makev func() (interface{}, error)) (v interface{}, err
 
 	v, ok := cache[key]
 	if ok {
+		metrics.CacheRequests.WithLabelValues(key.String(), "hit").Inc()
 		return
 	}
+	metrics.CacheRequests.WithLabelValues(key.String(), "miss").Inc()
 
 	if v, err = makev(); err != nil {
 		return
@@ -35,10 +54,20 @@ func Cached(key cacheKey, makev func() (interface{}, error)) (v interface{}, err
 	return
 }
 
-// ClearProfilesCache wipes cached profiles info.
-// Should be called on DB update.
+// ClearProfilesCache wipes cached profiles info and search index built
+// from it. Should be called on DB update.
 func ClearProfilesCache() {
 	mu.Lock()
 	defer mu.Unlock()
 	delete(cache, ProfileCacheKey)
+	delete(cache, ProfileDataCacheKey)
+	delete(cache, SearchIndexCacheKey)
+}
+
+// ClearTrainDataCache wipes cached train data. Should be called on faces
+// update.
+func ClearTrainDataCache() {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(cache, TrainDataCacheKey)
 }
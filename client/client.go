@@ -0,0 +1,286 @@
+// Package client is a Go client of kpopnet API described by
+// /api/openapi.json.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is an error returned by API.
+type Error struct {
+	Message string                 `json:"error"`
+	Code    string                 `json:"code"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Status is an HTTP status code of the response.
+	Status int `json:"-"`
+	// RetryAfter is how long to wait before retrying rate limited
+	// request.
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// Options of API client.
+type Options struct {
+	// API key, empty means anonymous requests.
+	APIKey string
+	// HTTP client to make requests with, nil means http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Client of kpopnet API.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// New creates client of API available at baseURL, e.g.
+// https://kpopnet.example.com.
+func New(baseURL string, opts Options) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     opts.APIKey,
+		httpClient: opts.HTTPClient,
+	}
+	if c.httpClient == nil {
+		c.httpClient = http.DefaultClient
+	}
+	return c
+}
+
+// Make API request and decode JSON response into v.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, ctype string, body io.Reader, v interface{}) (err error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json")
+	if ctype != "" {
+		req.Header.Set("Content-Type", ctype)
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return decodeError(res)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+func decodeError(res *http.Response) error {
+	apiErr := &Error{}
+	data, _ := ioutil.ReadAll(res.Body)
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Code == "" {
+		// Not an API error, e.g. from proxy.
+		apiErr = &Error{Message: res.Status}
+	}
+	apiErr.Status = res.StatusCode
+	if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(secs) * time.Second
+	}
+	return apiErr
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	return c.do(ctx, "GET", path, query, "", nil, v)
+}
+
+func (c *Client) postJSON(ctx context.Context, path string, req interface{}, v interface{}) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, "POST", path, nil, "application/json", bytes.NewReader(data), v)
+}
+
+// Upload images as files[] with additional form fields.
+func (c *Client) postFiles(ctx context.Context, path string, images []io.Reader, fields map[string]string, v interface{}) (err error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for i, img := range images {
+		var fw io.Writer
+		if fw, err = mw.CreateFormFile("files[]", fmt.Sprintf("image%d", i)); err != nil {
+			return
+		}
+		if _, err = io.Copy(fw, img); err != nil {
+			return
+		}
+	}
+	for name, value := range fields {
+		if err = mw.WriteField(name, value); err != nil {
+			return
+		}
+	}
+	if err = mw.Close(); err != nil {
+		return
+	}
+	return c.do(ctx, "POST", path, nil, mw.FormDataContentType(), &buf, v)
+}
+
+// Profiles returns all bands and idols.
+func (c *Client) Profiles(ctx context.Context) (ps *Profiles, err error) {
+	err = c.get(ctx, "/api/profiles", nil, &ps)
+	return
+}
+
+// ProfilesSince returns bands and idols changed after the given
+// revision together with IDs of deleted ones.
+func (c *Client) ProfilesSince(ctx context.Context, revision int64) (ps *Profiles, err error) {
+	query := url.Values{"since": {strconv.FormatInt(revision, 10)}}
+	err = c.get(ctx, "/api/profiles", query, &ps)
+	return
+}
+
+// ProfilesQuery filters, sorts and paginates profiles. Zero values are
+// not sent.
+type ProfilesQuery struct {
+	Offset     int
+	Limit      int
+	BandID     string
+	Agency     string
+	DebutYear  int
+	HasPreview *bool
+	// Sort key: name, birth_date or debut_date, optionally prefixed with
+	// minus for descending order.
+	Sort   string
+	Fields []string
+}
+
+func (q ProfilesQuery) values() url.Values {
+	query := url.Values{}
+	// Makes response a page even without other parameters.
+	query.Set("offset", strconv.Itoa(q.Offset))
+	if q.Limit > 0 {
+		query.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.BandID != "" {
+		query.Set("band", q.BandID)
+	}
+	if q.Agency != "" {
+		query.Set("agency", q.Agency)
+	}
+	if q.DebutYear > 0 {
+		query.Set("debut_year", strconv.Itoa(q.DebutYear))
+	}
+	if q.HasPreview != nil {
+		query.Set("has_preview", strconv.FormatBool(*q.HasPreview))
+	}
+	if q.Sort != "" {
+		query.Set("sort", q.Sort)
+	}
+	if len(q.Fields) > 0 {
+		query.Set("fields", strings.Join(q.Fields, ","))
+	}
+	return query
+}
+
+// ProfilesPage returns page of profiles matching the query.
+func (c *Client) ProfilesPage(ctx context.Context, q ProfilesQuery) (page *ProfilesPage, err error) {
+	err = c.get(ctx, "/api/profiles", q.values(), &page)
+	return
+}
+
+// Idol returns single idol.
+func (c *Client) Idol(ctx context.Context, id string) (idol *Idol, err error) {
+	err = c.get(ctx, "/api/idols/"+url.PathEscape(id), nil, &idol)
+	return
+}
+
+// SimilarIdols returns idols which look like the given one, closest
+// first. Zero limit means server default.
+func (c *Client) SimilarIdols(ctx context.Context, id string, limit int) (similar []*Similar, err error) {
+	var query url.Values
+	if limit > 0 {
+		query = url.Values{"limit": {strconv.Itoa(limit)}}
+	}
+	err = c.get(ctx, "/api/idols/"+url.PathEscape(id)+"/similar", query, &similar)
+	return
+}
+
+// Band returns single band.
+func (c *Client) Band(ctx context.Context, id string) (band *Band, err error) {
+	err = c.get(ctx, "/api/bands/"+url.PathEscape(id), nil, &band)
+	return
+}
+
+// BandIdols returns all members of the band.
+func (c *Client) BandIdols(ctx context.Context, id string) (idols []*Idol, err error) {
+	err = c.get(ctx, "/api/bands/"+url.PathEscape(id)+"/idols", nil, &idols)
+	return
+}
+
+// Search returns idols and bands matching the query, best first. Zero
+// limit means server default.
+func (c *Client) Search(ctx context.Context, q string, limit int) (results []*SearchResult, err error) {
+	query := url.Values{"q": {q}}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	err = c.get(ctx, "/api/search", query, &results)
+	return
+}
+
+// Image already available to the server.
+type imageRef struct {
+	ImageID string `json:"image_id,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Recognize recognizes idol on the uploaded image.
+func (c *Client) Recognize(ctx context.Context, img io.Reader) (res *Result, err error) {
+	err = c.postFiles(ctx, "/api/recognize", []io.Reader{img}, nil, &res)
+	return
+}
+
+// RecognizeURL recognizes idol on the image which server fetches by
+// URL.
+func (c *Client) RecognizeURL(ctx context.Context, imageURL string) (res *Result, err error) {
+	err = c.postJSON(ctx, "/api/recognize", imageRef{URL: imageURL}, &res)
+	return
+}
+
+// RecognizeImageID recognizes idol on the image already uploaded to the
+// server's file store.
+func (c *Client) RecognizeImageID(ctx context.Context, imageID string) (res *Result, err error) {
+	err = c.postJSON(ctx, "/api/recognize", imageRef{ImageID: imageID}, &res)
+	return
+}
+
+// Verify checks whether two uploaded images show the same person.
+func (c *Client) Verify(ctx context.Context, img1, img2 io.Reader) (v *Verification, err error) {
+	err = c.postFiles(ctx, "/api/verify", []io.Reader{img1, img2}, nil, &v)
+	return
+}
+
+// VerifyIdol checks whether uploaded image shows the given idol.
+func (c *Client) VerifyIdol(ctx context.Context, img io.Reader, idolID string) (v *Verification, err error) {
+	fields := map[string]string{"idol_id": idolID}
+	err = c.postFiles(ctx, "/api/verify", []io.Reader{img}, fields, &v)
+	return
+}
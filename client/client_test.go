@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Serve canned responses by request path, recording the last request.
+func testServer(t *testing.T, responses map[string]string) (*httptest.Server, *http.Request) {
+	var last http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Error(err)
+			}
+		}
+		last = *r
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(429)
+			body = `{"error": "too many requests", "code": "rate_limited", "details": {"retry_after": 30}}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	return ts, &last
+}
+
+func TestProfiles(t *testing.T) {
+	ts, last := testServer(t, map[string]string{
+		"/api/profiles": `{
+			"revision": 5,
+			"bands": [{"id": "b1", "name": "Twice", "agency_name": "JYP", "unknown": 1}],
+			"idols": [{"id": "i1", "band_id": "b1", "name": "Tzuyu", "height": 172}],
+			"memberships": [{"idol_id": "i1", "band_id": "b1"}],
+			"total_bands": 1,
+			"total_idols": 1
+		}`,
+	})
+	defer ts.Close()
+	c := New(ts.URL+"/", Options{APIKey: "secret"})
+	ctx := context.Background()
+
+	ps, err := c.Profiles(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ps.Revision != 5 || ps.Bands[0].AgencyName != "JYP" || ps.Idols[0].Height != 172 || ps.Memberships[0].IdolID != "i1" {
+		t.Errorf("bad profiles: %+v", ps)
+	}
+	if auth := last.Header.Get("Authorization"); auth != "Bearer secret" {
+		t.Errorf("unexpected authorization %q", auth)
+	}
+
+	if _, err := c.ProfilesSince(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if since := last.URL.Query().Get("since"); since != "3" {
+		t.Errorf("unexpected since %q", since)
+	}
+
+	hasPreview := true
+	page, err := c.ProfilesPage(ctx, ProfilesQuery{
+		Limit:      10,
+		HasPreview: &hasPreview,
+		Sort:       "-name",
+		Fields:     []string{"name", "band_id"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalIdols != 1 || page.Idols[0].Name != "Tzuyu" {
+		t.Errorf("bad page: %+v", page)
+	}
+	expected := "fields=name%2Cband_id&has_preview=true&limit=10&offset=0&sort=-name"
+	if query := last.URL.RawQuery; query != expected {
+		t.Errorf("unexpected query %q", query)
+	}
+}
+
+func TestRecognize(t *testing.T) {
+	result := `{
+		"id": "i1",
+		"distance": 0.3,
+		"rectangle": {"x": 1, "y": 2, "width": 100, "height": 100},
+		"landmarks": [{"x": 10, "y": 10}],
+		"image": {"width": 640, "height": 480}
+	}`
+	ts, last := testServer(t, map[string]string{
+		"/api/recognize": result,
+		"/api/verify":    `{"match": true, "distance": 0.4, "threshold": 0.6}`,
+	})
+	defer ts.Close()
+	c := New(ts.URL, Options{})
+	ctx := context.Background()
+
+	res, err := c.Recognize(ctx, strings.NewReader("image data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &Result{
+		IdolID:    "i1",
+		Distance:  0.3,
+		Rectangle: Rect{1, 2, 100, 100},
+		Landmarks: []Point{{10, 10}},
+		Image:     Size{640, 480},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("bad result: %+v", res)
+	}
+	fhs := last.MultipartForm.File["files[]"]
+	if len(fhs) != 1 {
+		t.Fatalf("expected single file but got %d", len(fhs))
+	}
+	fd, _ := fhs[0].Open()
+	data, _ := ioutil.ReadAll(fd)
+	if string(data) != "image data" {
+		t.Errorf("unexpected file %q", data)
+	}
+	if auth := last.Header.Get("Authorization"); auth != "" {
+		t.Errorf("anonymous client sent %q", auth)
+	}
+
+	if _, err := c.RecognizeURL(ctx, "https://example.com/a.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := c.VerifyIdol(ctx, strings.NewReader("image data"), "i1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Match || last.MultipartForm.Value["idol_id"][0] != "i1" {
+		t.Errorf("bad verification: %+v", v)
+	}
+}
+
+func TestError(t *testing.T) {
+	ts, _ := testServer(t, nil)
+	defer ts.Close()
+	c := New(ts.URL, Options{})
+	_, err := c.Search(context.Background(), "twice", 0)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected API error but got %v", err)
+	}
+	if apiErr.Status != 429 || apiErr.Code != "rate_limited" || apiErr.RetryAfter != 30*time.Second {
+		t.Errorf("bad error: %+v", apiErr)
+	}
+
+	// Errors not from API are still reported with status.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(502)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	}))
+	defer proxy.Close()
+	_, err = New(proxy.URL, Options{}).Idol(context.Background(), "i1")
+	if !errors.As(err, &apiErr) || apiErr.Status != 502 {
+		t.Errorf("unexpected error %v", err)
+	}
+}
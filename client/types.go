@@ -0,0 +1,117 @@
+package client
+
+// Types mirror API schemas. They are declared here instead of reusing
+// server ones so client can be built without dlib. Unknown fields of
+// bands and idols are ignored.
+
+// Band info.
+type Band struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	AltNames    []string `json:"alt_names,omitempty"`
+	KoreanName  string   `json:"korean_name,omitempty"`
+	AgencyName  string   `json:"agency_name,omitempty"`
+	DebutDate   string   `json:"debut_date,omitempty"`
+	DisbandDate string   `json:"disband_date,omitempty"`
+	URLs        []string `json:"urls,omitempty"`
+}
+
+// Idol info.
+type Idol struct {
+	ID         string   `json:"id"`
+	BandID     string   `json:"band_id"`
+	ImageID    string   `json:"image_id,omitempty"`
+	Name       string   `json:"name"`
+	AltNames   []string `json:"alt_names,omitempty"`
+	BirthName  string   `json:"birth_name,omitempty"`
+	KoreanName string   `json:"korean_name,omitempty"`
+	BirthDate  string   `json:"birth_date,omitempty"`
+	DebutDate  string   `json:"debut_date,omitempty"`
+	Height     float64  `json:"height,omitempty"`
+	Weight     float64  `json:"weight,omitempty"`
+	Positions  []string `json:"positions,omitempty"`
+	URLs       []string `json:"urls,omitempty"`
+}
+
+// Membership describes idol's participation in a band.
+type Membership struct {
+	IdolID    string `json:"idol_id"`
+	BandID    string `json:"band_id"`
+	Role      string `json:"role,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// Profiles contains all bands and idols or only changed ones in case of
+// incremental sync.
+type Profiles struct {
+	Revision     int64         `json:"revision"`
+	Bands        []*Band       `json:"bands"`
+	Idols        []*Idol       `json:"idols"`
+	Memberships  []*Membership `json:"memberships"`
+	DeletedBands []string      `json:"deleted_bands,omitempty"`
+	DeletedIdols []string      `json:"deleted_idols,omitempty"`
+}
+
+// ProfilesPage is a filtered, sorted and paginated part of profiles.
+// Only requested fields of bands and idols are set if query has Fields.
+type ProfilesPage struct {
+	Profiles
+	TotalBands int `json:"total_bands"`
+	TotalIdols int `json:"total_idols"`
+}
+
+// Similar describes idol which looks like another one.
+type Similar struct {
+	IdolID   string  `json:"id"`
+	Distance float64 `json:"distance"`
+}
+
+// SearchResult is a single search hit. Only one of Idol/Band is set.
+type SearchResult struct {
+	Score float64 `json:"score"`
+	Idol  *Idol   `json:"idol,omitempty"`
+	Band  *Band   `json:"band,omitempty"`
+}
+
+// Point is a point on the image.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Rect is a rectangle on the image.
+type Rect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Size is a size of the image.
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Result describes recognized face.
+type Result struct {
+	// ID of the recognized idol.
+	IdolID string `json:"id"`
+	// Distance between the face and the closest sample of the idol.
+	Distance  float64 `json:"distance"`
+	Rectangle Rect    `json:"rectangle"`
+	Landmarks []Point `json:"landmarks"`
+	// Size of the source image.
+	Image Size `json:"image"`
+}
+
+// Verification tells whether faces belong to the same person.
+type Verification struct {
+	Match    bool    `json:"match"`
+	Distance float64 `json:"distance"`
+	// Only set on idol verification.
+	MeanDistance float64 `json:"mean_distance,omitempty"`
+	Samples      int     `json:"samples,omitempty"`
+	Threshold    float64 `json:"threshold"`
+}
@@ -1,11 +1,20 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/kpopnet/go-kpopnet"
 	"github.com/kpopnet/go-kpopnet/db"
 	"github.com/kpopnet/go-kpopnet/facerec"
+	"github.com/kpopnet/go-kpopnet/logging"
 	"github.com/kpopnet/go-kpopnet/server"
 
 	"github.com/BurntSushi/toml"
@@ -21,53 +30,280 @@ K-pop face recognition backend.
 
 Usage:
   kpopnetd [options]
+  kpopnetd audit-faces [options]
+  kpopnetd cluster-faces [options]
+  kpopnetd create-api-key <name> <scope>... [options]
+  kpopnetd revoke-api-key <id> [options]
   kpopnetd [-h | --help]
   kpopnetd [-V | --version]
 
+Scopes of API keys: read, recognize, admin.
+
 Options:
-  -h --help      Show this screen.
-  -V --version   Show version.
-  -H <host>      Host to listen on [default: 127.0.0.1].
-  -p <port>      Port to listen on [default: 8002].
-  -c <conn>      PostgreSQL connection string
-                 [default: user=meguca password=meguca dbname=meguca sslmode=disable].
-  -m <modeldir>  Model directory location [default: ./testdata/models].
-  --cfg <path>   Path to TOML config.
+  -h --help         Show this screen.
+  -V --version      Show version.
+  -H <host>         Host to listen on [default: 127.0.0.1].
+  -p <port>         Port to listen on [default: 8002].
+  -c <conn>         PostgreSQL connection string
+                    [default: user=meguca password=meguca dbname=meguca sslmode=disable].
+  -m <modeldir>     Model directory location [default: ./testdata/models].
+  --max-age <s>     Cache-Control max-age of API responses [default: 0].
+  --warm            Load train data on start.
+  --log-level <l>   Log level: debug, info, warn or error [default: info].
+  --log-format <f>  Log format: logfmt or json [default: logfmt].
+  --cfg <path>      Path to TOML config.
 `
 
 type config struct {
-	Host     string `docopt:"-H"`
-	Port     int    `docopt:"-p"`
-	Conn     string `docopt:"-c"`
-	ModelDir string `docopt:"-m"`
-	Path     string `docopt:"--cfg"`
+	Host            string   `docopt:"-H"`
+	Port            int      `docopt:"-p"`
+	Conn            string   `docopt:"-c"`
+	ModelDir        string   `docopt:"-m"`
+	CacheMaxAge     int      `docopt:"--max-age" toml:"cache_max_age"`
+	ImagePath       string   `toml:"image_path"`
+	FetchHosts      []string `toml:"fetch_hosts"`
+	FetchTimeout    int      `toml:"fetch_timeout"`
+	JobRetention    int      `toml:"job_retention"`
+	CallbackHosts   []string `toml:"callback_hosts"`
+	EnableAdmin     bool     `toml:"enable_admin"`
+	MaxAdminFaces   int      `toml:"max_admin_faces"`
+	WarmTrainData   bool     `docopt:"--warm" toml:"warm_train_data"`
+	AuditFaces      bool     `docopt:"audit-faces"`
+	ClusterFaces    bool     `docopt:"cluster-faces"`
+	CreateAPIKey    bool     `docopt:"create-api-key"`
+	RevokeAPIKey    bool     `docopt:"revoke-api-key"`
+	KeyName         string   `docopt:"<name>"`
+	KeyScopes       []string `docopt:"<scope>"`
+	KeyID           int64    `docopt:"<id>"`
+	LogLevel        string   `docopt:"--log-level" toml:"log_level"`
+	LogFormat       string   `docopt:"--log-format" toml:"log_format"`
+	ShutdownTimeout int      `toml:"shutdown_timeout"`
+	Socket          string   `toml:"socket"`
+	SocketMode      string   `toml:"socket_mode"`
+	TLSCert         string   `toml:"tls_cert"`
+	TLSKey          string   `toml:"tls_key"`
+	RequireAPIKey   bool     `toml:"require_api_key"`
+	KeyRateLimit    float64  `toml:"key_rate_limit"`
+	KeyRateBurst    int      `toml:"key_rate_burst"`
+	IPRateLimit     float64  `toml:"ip_rate_limit"`
+	IPRateBurst     int      `toml:"ip_rate_burst"`
+	IPHeader        string   `toml:"ip_header"`
+	CORSOrigins     []string `toml:"cors_origins"`
+	CORSMethods     []string `toml:"cors_methods"`
+	CORSHeaders     []string `toml:"cors_headers"`
+	CORSCredentials bool     `toml:"cors_credentials"`
+	CORSMaxAge      int      `toml:"cors_max_age"`
+	Path            string   `docopt:"--cfg"`
+}
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// Apply TOML config on top of command line options.
+func loadConfig(base config) (conf config, err error) {
+	conf = base
+	// API is public by default, empty list in config disables CORS.
+	conf.CORSOrigins = []string{"*"}
+	if conf.Path != "" {
+		_, err = toml.DecodeFile(conf.Path, &conf)
+	}
+	return
+}
+
+func setupLogging(conf config) error {
+	return logging.Setup(logging.Options{
+		Level:  conf.LogLevel,
+		Format: conf.LogFormat,
+	})
+}
+
+func serverOptions(conf config) (opts server.Options, err error) {
+	var mode uint64
+	if conf.SocketMode != "" {
+		if mode, err = strconv.ParseUint(conf.SocketMode, 8, 32); err != nil {
+			err = fmt.Errorf("bad socket mode %q", conf.SocketMode)
+			return
+		}
+	}
+	if (conf.TLSCert == "") != (conf.TLSKey == "") {
+		err = errors.New("both TLS certificate and key should be set")
+		return
+	}
+	opts = server.Options{
+		Address:       fmt.Sprintf("%v:%v", conf.Host, conf.Port),
+		Socket:        conf.Socket,
+		SocketMode:    os.FileMode(mode),
+		TLSCert:       conf.TLSCert,
+		TLSKey:        conf.TLSKey,
+		CacheMaxAge:   conf.CacheMaxAge,
+		ImagePath:     conf.ImagePath,
+		FetchHosts:    conf.FetchHosts,
+		FetchTimeout:  conf.FetchTimeout,
+		JobRetention:  conf.JobRetention,
+		CallbackHosts: conf.CallbackHosts,
+		EnableAdmin:   conf.EnableAdmin,
+		MaxAdminFaces: conf.MaxAdminFaces,
+		RequireAPIKey: conf.RequireAPIKey,
+		KeyRateLimit: server.RateLimit{
+			PerMinute: conf.KeyRateLimit,
+			Burst:     conf.KeyRateBurst,
+		},
+		IPRateLimit: server.RateLimit{
+			PerMinute: conf.IPRateLimit,
+			Burst:     conf.IPRateBurst,
+		},
+		CORS: server.CORSOptions{
+			Origins:     conf.CORSOrigins,
+			Methods:     conf.CORSMethods,
+			Headers:     conf.CORSHeaders,
+			Credentials: conf.CORSCredentials,
+			MaxAge:      conf.CORSMaxAge,
+		},
+		IPHeader: conf.IPHeader,
+	}
+	return
+}
+
+func fatal(err error) {
+	logging.Fatal(context.Background(), err.Error())
 }
 
-func serve(conf config) {
+func serve(base config, conf config) {
 	if err := db.Start(nil, conf.Conn); err != nil {
-		log.Fatal(err)
+		fatal(err)
+	}
+	if err := facerec.Start(facerec.Options{
+		ModelDir:      conf.ModelDir,
+		WarmTrainData: conf.WarmTrainData,
+	}); err != nil {
+		fatal(err)
+	}
+	opts, err := serverOptions(conf)
+	if err != nil {
+		fatal(err)
+	}
+	stopped := make(chan struct{})
+	go handleSignals(base, stopped)
+	if opts.Socket != "" {
+		logging.Info(context.Background(), "listening", "socket", opts.Socket)
+	} else {
+		logging.Info(context.Background(), "listening", "address", opts.Address)
 	}
-	if err := facerec.Start(conf.ModelDir); err != nil {
-		log.Fatal(err)
+	if err := server.Start(opts); err != nil {
+		fatal(err)
+	}
+	<-stopped
+}
+
+// Shutdown gracefully on SIGTERM/SIGINT, reload config and data on
+// SIGHUP. base is config made from command line options.
+func handleSignals(base config, stopped chan<- struct{}) {
+	ctx := context.Background()
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+	for sig := range sigs {
+		if sig == syscall.SIGHUP {
+			logging.Info(ctx, "reloading")
+			if err := reload(ctx, base); err != nil {
+				logging.Error(ctx, "error reloading", "error", err)
+			}
+			continue
+		}
+		logging.Info(ctx, "shutting down", "signal", sig)
+		// Second signal kills the process without waiting.
+		signal.Stop(sigs)
+		conf, _ := loadConfig(base)
+		timeout := defaultShutdownTimeout
+		if conf.ShutdownTimeout > 0 {
+			timeout = time.Duration(conf.ShutdownTimeout) * time.Second
+		}
+		shutdownCtx, cancel := context.WithTimeout(ctx, timeout)
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logging.Error(ctx, "error shutting down server", "error", err)
+		}
+		if err := facerec.Stop(shutdownCtx); err != nil {
+			logging.Error(ctx, "error stopping recognizer", "error", err)
+		}
+		cancel()
+		close(stopped)
+		return
+	}
+}
+
+// Re-read config and reload train data and profiles.
+func reload(ctx context.Context, base config) (err error) {
+	conf, err := loadConfig(base)
+	if err != nil {
+		return
+	}
+	if err = setupLogging(conf); err != nil {
+		return
+	}
+	opts, err := serverOptions(conf)
+	if err != nil {
+		return
+	}
+	if err = server.Reload(ctx, opts); err != nil {
+		return
+	}
+	return facerec.ReloadTrainData(ctx)
+}
+
+// Print JSON report made by the given function.
+func printReport(conf config, makeReport func() (interface{}, error)) {
+	if err := db.Start(nil, conf.Conn); err != nil {
+		fatal(err)
+	}
+	report, err := makeReport()
+	if err != nil {
+		fatal(err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fatal(err)
 	}
-	address := fmt.Sprintf("%v:%v", conf.Host, conf.Port)
-	log.Printf("Listening on %v", address)
-	log.Fatal(server.Start(address))
 }
 
 func main() {
 	opts, err := docopt.ParseArgs(USAGE, nil, VERSION)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
-	var conf config
-	if err := opts.Bind(&conf); err != nil {
-		log.Fatal(err)
+	var base config
+	if err := opts.Bind(&base); err != nil {
+		fatal(err)
 	}
-	if conf.Path != "" {
-		if _, err := toml.DecodeFile(conf.Path, &conf); err != nil {
-			log.Fatal(err)
-		}
+	conf, err := loadConfig(base)
+	if err != nil {
+		fatal(err)
+	}
+	if err := setupLogging(conf); err != nil {
+		fatal(err)
+	}
+	switch {
+	case conf.AuditFaces:
+		printReport(conf, func() (interface{}, error) {
+			return facerec.AuditFaces(context.Background(), facerec.AuditOptions{})
+		})
+	case conf.ClusterFaces:
+		printReport(conf, func() (interface{}, error) {
+			return facerec.ClusterFaces(context.Background(), facerec.ClusterOptions{})
+		})
+	case conf.CreateAPIKey:
+		printReport(conf, func() (interface{}, error) {
+			scopes := make([]kpopnet.Scope, len(conf.KeyScopes))
+			for i, scope := range conf.KeyScopes {
+				scopes[i] = kpopnet.Scope(scope)
+			}
+			key, secret, err := db.CreateAPIKey(context.Background(), conf.KeyName, scopes)
+			return map[string]interface{}{"key": key, "secret": secret}, err
+		})
+	case conf.RevokeAPIKey:
+		printReport(conf, func() (interface{}, error) {
+			revoked, err := db.RevokeAPIKey(context.Background(), conf.KeyID)
+			return map[string]interface{}{"id": conf.KeyID, "revoked": revoked}, err
+		})
+	default:
+		serve(base, conf)
 	}
-	serve(conf)
 }
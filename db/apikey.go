@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	k "github.com/kpopnet/go-kpopnet"
+	"github.com/lib/pq"
+)
+
+func hashAPIKey(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// Generate random key with prefix to make it recognizable.
+func newAPIKeySecret() string {
+	var b [24]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return "kp_" + hex.EncodeToString(b[:])
+}
+
+// CreateAPIKey stores new API key with given scopes. Returned secret
+// can't be recovered later.
+func CreateAPIKey(ctx context.Context, name string, scopes []k.Scope) (key *k.APIKey, secret string, err error) {
+	if name == "" {
+		err = errors.New("empty key name")
+		return
+	}
+	if len(scopes) == 0 {
+		err = errors.New("no scopes")
+		return
+	}
+	strScopes := make([]string, len(scopes))
+	for i, scope := range scopes {
+		if !k.ValidScope(scope) {
+			err = fmt.Errorf("unknown scope %q", scope)
+			return
+		}
+		strScopes[i] = string(scope)
+	}
+	secret = newAPIKeySecret()
+	key = &k.APIKey{Name: name, Scopes: scopes}
+	defer observeQuery("insert_api_key")()
+	err = prepared["insert_api_key"].
+		QueryRowContext(ctx, hashAPIKey(secret), name, pq.Array(strScopes)).
+		Scan(&key.ID, &key.CreatedAt)
+	return
+}
+
+// GetAPIKey returns active API key by its secret or nil if there is no
+// such key.
+func GetAPIKey(ctx context.Context, secret string) (key *k.APIKey, err error) {
+	var strScopes []string
+	key = &k.APIKey{}
+	defer observeQuery("get_api_key")()
+	err = prepared["get_api_key"].
+		QueryRowContext(ctx, hashAPIKey(secret)).
+		Scan(&key.ID, &key.Name, pq.Array(&strScopes), &key.CreatedAt)
+	if err == sql.ErrNoRows {
+		key = nil
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	for _, scope := range strScopes {
+		key.Scopes = append(key.Scopes, k.Scope(scope))
+	}
+	return
+}
+
+// RevokeAPIKey disables API key. Returns false if there is no active key
+// with such ID.
+func RevokeAPIKey(ctx context.Context, id int64) (revoked bool, err error) {
+	defer observeQuery("revoke_api_key")()
+	res, err := prepared["revoke_api_key"].ExecContext(ctx, id)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	revoked = n > 0
+	return
+}
@@ -1,10 +1,30 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// sql/get_bands.sql (27B)
+// sql/fn_memberships.sql (557B)
+// sql/fn_profile_rev.sql (1.962kB)
+// sql/get_api_key.sql (93B)
+// sql/get_band.sql (37B)
+// sql/get_band_idols.sql (289B)
+// sql/get_bands.sql (42B)
+// sql/get_deletions.sql (59B)
+// sql/get_faces.sql (90B)
+// sql/get_idol.sql (121B)
+// sql/get_idol_exists.sql (50B)
 // sql/get_idol_previews.sql (39B)
-// sql/get_idols.sql (36B)
+// sql/get_idols.sql (51B)
+// sql/get_image_exists.sql (53B)
+// sql/get_label_conflicts.sql (454B)
+// sql/get_memberships.sql (278B)
+// sql/get_revision.sql (283B)
 // sql/get_train_data.sql (83B)
-// sql/init_db.sql (1.116kB)
+// sql/init_db.sql (3.202kB)
+// sql/insert_api_key.sql (91B)
+// sql/insert_idol.sql (58B)
+// sql/insert_migration.sql (63B)
+// sql/label_faces.sql (99B)
+// sql/migrate_001_txid_revisions.sql (957B)
+// sql/migrate_002_primary_memberships.sql (169B)
+// sql/revoke_api_key.sql (76B)
 
 package db
 
@@ -73,7 +93,107 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _get_bandsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0a\x76\xf5\x71\x75\x0e\x51\xc8\x4c\xd1\x51\x48\x49\x2c\x49\x54\x70\x0b\xf2\xf7\x55\x48\x4a\xcc\x4b\x29\xe6\x02\x04\x00\x00\xff\xff\x14\x21\x0f\x5f\x1b\x00\x00\x00")
+var _fn_membershipsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x50\x4d\x8e\xd3\x30\x18\xdd\xfb\x14\x6f\x91\x45\x47\x22\xbd\x40\x56\xc6\xf9\x92\xb1\x08\x76\xe4\xd8\xcc\xec\xa2\x0c\xf1\x74\x2c\xa5\x6d\x88\x03\x88\xdb\x23\xb7\x4d\x01\x09\x58\xd9\xf2\xf3\xfb\xcd\x73\x7c\xf0\x7e\xc6\xbc\x84\xe3\xb0\xfc\xc0\xcb\x70\x1a\x71\x7e\x45\x18\xcf\x13\x06\x1c\xfd\xf1\xc5\x2f\xf1\x2d\xcc\x7b\x7c\xbc\xdf\xd3\x87\xf5\xcd\x63\x5e\xfc\xb7\x70\xfe\x1a\x59\x9e\xff\x29\x10\x22\x26\xff\xba\x62\x88\x08\x11\x31\x9c\x3e\xfb\xab\xe2\xf7\xf4\xb2\xc6\x9b\xee\x9e\x09\x43\xdc\x12\xb4\x81\xa1\xb6\xe1\x82\x50\x39\x25\xac\xd4\x0a\xc3\x38\xf6\x37\xd1\xfe\x57\x8c\xdd\x03\x0c\x59\x67\x54\x87\x75\x09\x87\x83\x5f\xc0\x3b\x64\x19\x7b\x4f\xb5\x54\x0c\x90\xaa\x23\x63\x21\x95\xd5\xbf\xa5\x8f\xd8\x25\xff\x3e\x8c\xef\x2e\x09\xfb\x30\x3e\xe0\x13\x6f\x1c\x75\xd8\x29\x7a\xda\x27\x20\x9d\x1b\xc8\x00\xad\x20\xb4\xaa\x1a\x29\x2c\x4a\x0d\xa5\xed\xa3\x54\x75\xc1\x70\x4b\x00\xe5\x9a\xa6\x60\xa4\xca\x82\x65\x19\x1a\xae\x6a\xc7\x6b\xc2\x3c\xcd\x87\xf8\x65\x2a\x18\x2b\x8d\x6e\x61\x8d\xac\x6b\x32\x90\x15\xe8\x59\x76\xb6\xbb\x2c\x11\xfb\xbf\xf7\x4b\xae\x17\xbc\xd8\xb6\xd9\xf8\xff\x65\xf1\xca\x26\x8b\x6b\x77\x6d\xe0\xda\x32\x71\x75\xb5\xb5\xbd\xeb\x32\xa0\xd2\x06\xc4\xc5\x23\x8c\x7e\x02\x3d\x93\x70\x96\xd0\x1a\x2d\xa8\x74\x86\xfe\x39\x7c\xc1\x7e\x0e\x00\xca\x0b\xf6\xdf\x2d\x02\x00\x00")
+
+func fn_membershipsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_fn_membershipsSql,
+		"fn_memberships.sql",
+	)
+}
+
+func fn_membershipsSql() (*asset, error) {
+	bytes, err := fn_membershipsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "fn_memberships.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xcf, 0xbd, 0xc4, 0x19, 0x45, 0xf2, 0x29, 0xfd, 0xdf, 0xb3, 0x93, 0x16, 0x7c, 0xc, 0x62, 0xa1, 0x87, 0xbc, 0x50, 0xa2, 0x90, 0xe7, 0x11, 0x8a, 0xbb, 0xba, 0x67, 0xb0, 0xe0, 0x65, 0xd, 0x7f}}
+	return a, nil
+}
+
+var _fn_profile_revSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\x5f\x6f\xe2\x3e\x10\x7c\xcf\xa7\x98\x07\x24\x82\x54\xd0\xef\xb9\xfc\x5a\x29\x25\x4b\x1a\x29\x4a\x90\x49\x4a\xa5\xd3\x29\x0a\xc4\x05\xdf\x81\x43\x1d\xa7\xbd\xfb\xf6\x27\xf3\xa7\x85\x94\x12\x2a\xf5\xd5\x3b\xb3\x9e\x99\xf5\xba\xdb\x05\xe3\x2f\xa2\x14\x85\x84\x28\xe1\xbb\x28\x9e\xa0\x17\x1c\xaf\x4a\x68\x21\xe7\xd0\x2a\x93\x65\x36\xd3\xa2\x90\x57\x28\x39\xc7\x9c\xeb\x54\xed\x28\xbd\xf2\x79\xd9\xb3\x06\x8c\x9c\x98\x10\x31\x30\x1a\x05\xce\x80\x30\x4c\xc2\x41\xec\x47\x21\xa6\xd5\x6a\x6d\xd0\x76\x07\x8c\xe2\x84\x85\x63\x68\x25\xe6\x73\xae\xe0\x8c\xd1\x6a\x59\x77\xe4\xf9\xa1\x05\x84\x34\xe9\x29\xfe\x82\xeb\x1b\xe8\x3f\x22\x4f\x67\x95\x52\x5c\x6a\xbb\xd3\xdf\x15\xab\x75\x9e\x69\x9e\xa7\x99\x36\x18\x59\xbc\x6e\x4b\xdb\xae\x06\xd1\xb7\x28\x74\xfb\x56\xab\x85\xc0\x09\xbd\xc4\xf1\x08\xeb\xe5\x7a\x5e\x3e\x2f\xfb\x96\xd5\xed\x62\x28\x54\xa9\x91\xa9\x79\xb5\xe2\x52\x1b\xb3\x5c\x6a\xa1\xff\xe2\xb7\x90\xf9\x59\x13\x8a\xcf\x0a\x95\xa7\x39\x5f\x72\x13\x43\xa3\x17\x3f\x1c\x13\x8b\xe1\x87\x71\x84\x3d\xa9\x84\x6d\xee\xb9\x82\xc8\x3b\x78\x70\x82\x84\xc6\xb0\x63\x2f\x75\x98\xf7\xf0\xe3\xbf\x9f\x57\x88\x02\xb7\x27\xf2\x23\x4f\x49\x10\x34\x99\xba\xab\x56\x6b\xec\xa7\x81\xe2\x09\x22\x2f\x96\x28\x24\x14\x5f\x9a\xb8\x90\x67\x3a\xc3\x6c\x91\xc9\x39\xef\x9d\x88\x40\x2f\xb8\x69\x23\xb3\x15\x7f\x63\xfb\x2e\x66\xc5\xb2\x5a\xc9\xb3\xa1\xe8\xa2\x9a\x2d\x52\x43\x68\xce\x63\x88\xd8\x4b\xa3\x11\xfe\xbf\x45\x7b\x1b\x4e\x1b\xf1\x3d\x99\xb9\x03\xc9\xc8\x35\xaf\xc7\x74\x2a\x31\xa6\x18\x07\x93\xde\x0d\x7a\x83\x9b\xdc\x13\x33\x30\xdc\xc0\xd6\x45\xfa\xab\x2c\xe4\xd4\x8e\x02\xb7\xd3\xbd\xbd\x7d\x0f\xb2\x73\x7d\x5d\x55\x22\x37\x31\x52\xe8\xc2\x1f\xf6\xeb\x02\x5c\x0a\x28\xa6\xef\x12\x10\xd2\xe4\x02\x01\x97\x4e\xd4\x65\xd1\x08\x31\xf3\x3d\x8f\x98\x89\x8d\x1e\xfd\x71\x3c\xc6\x34\x93\x79\x99\xee\x77\x09\x66\xaf\xcc\x49\x7f\x3f\x9f\x3d\xa3\x86\xbb\xa3\x61\xc4\x68\xef\x6f\xcf\xb2\x80\x61\xc4\x40\xce\xe0\x1e\x2c\x9a\x80\x1e\x69\x90\xc4\x84\x11\x8b\x06\xe4\x26\x8c\x0e\x96\xf6\x73\x49\x9b\xb4\x8e\x24\x6d\x4e\x3e\x48\xaa\xe1\x3e\x48\xda\xd4\xbf\x47\xd2\xd6\x7d\x6d\x59\x9b\xc2\xaa\xc3\x9d\x61\x4c\x0c\xdb\x37\xf2\x85\xc8\x6a\x6d\xec\xb6\xe1\xb5\xcf\x88\xdd\xf8\x3e\x25\xf6\x5c\x8c\x4d\x62\x2f\x0c\xb3\xd6\xc6\x6e\x1b\xde\x39\xb1\x2b\xbe\x9a\x72\x55\x2e\xc4\xba\x4c\xdf\xf7\xde\xdc\x78\x50\xf9\xa0\xf9\x13\xd6\x56\xf4\xee\x8f\x8c\xd8\xdb\x5b\x38\x34\x72\x40\x6d\xb6\xf3\xde\x7b\xeb\x24\x15\x8d\xc9\xa7\x6b\xf3\x67\xf2\xd7\xba\x9d\xa3\xda\xc9\x21\x9c\x64\x5e\x62\xe9\x88\xfc\x55\x53\xed\x4e\xdf\xfa\x37\x00\xb5\x41\x82\x2c\xaa\x07\x00\x00")
+
+func fn_profile_revSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_fn_profile_revSql,
+		"fn_profile_rev.sql",
+	)
+}
+
+func fn_profile_revSql() (*asset, error) {
+	bytes, err := fn_profile_revSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "fn_profile_rev.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xdc, 0xf4, 0x6e, 0x68, 0x2c, 0x3b, 0xd6, 0xed, 0x65, 0x79, 0x30, 0x7d, 0x26, 0x9c, 0xc, 0x9e, 0xbe, 0x97, 0x26, 0xc1, 0x8f, 0x19, 0x6b, 0xca, 0x1a, 0xcc, 0x77, 0x15, 0xd0, 0x3d, 0x7c, 0xb1}}
+	return a, nil
+}
+
+var _get_api_keySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5d\x00\xa2\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x2c\x20\x6e\x61\x6d\x65\x2c\x20\x73\x63\x6f\x70\x65\x73\x2c\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x20\x46\x52\x4f\x4d\x20\x61\x70\x69\x5f\x6b\x65\x79\x73\x0a\x57\x48\x45\x52\x45\x20\x6b\x65\x79\x5f\x68\x61\x73\x68\x20\x3d\x20\x24\x31\x20\x41\x4e\x44\x20\x72\x65\x76\x6f\x6b\x65\x64\x5f\x61\x74\x20\x49\x53\x20\x4e\x55\x4c\x4c\x0a\x03\x00\x26\x0e\xd3\xd2\x5d\x00\x00\x00")
+
+func get_api_keySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_api_keySql,
+		"get_api_key.sql",
+	)
+}
+
+func get_api_keySql() (*asset, error) {
+	bytes, err := get_api_keySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_api_key.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x50, 0xee, 0x6c, 0x30, 0xec, 0xfb, 0x9a, 0x14, 0xf1, 0x30, 0xf8, 0xc5, 0xf4, 0xf6, 0xd2, 0xc8, 0x3a, 0x60, 0xbf, 0x8c, 0xf7, 0xab, 0xfe, 0x1, 0xc9, 0x41, 0xca, 0x2d, 0x55, 0xeb, 0x2c, 0x59}}
+	return a, nil
+}
+
+var _get_bandSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x25\x00\xda\xff\x53\x45\x4c\x45\x43\x54\x20\x64\x61\x74\x61\x20\x46\x52\x4f\x4d\x20\x62\x61\x6e\x64\x73\x20\x57\x48\x45\x52\x45\x20\x69\x64\x20\x3d\x20\x24\x31\x0a\x03\x00\xe4\x91\x15\x64\x25\x00\x00\x00")
+
+func get_bandSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_bandSql,
+		"get_band.sql",
+	)
+}
+
+func get_bandSql() (*asset, error) {
+	bytes, err := get_bandSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_band.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x95, 0xbe, 0xb7, 0x32, 0x1d, 0x40, 0xd3, 0x48, 0xfe, 0x79, 0x4f, 0xb5, 0x2f, 0x94, 0x52, 0x36, 0xe, 0xbb, 0x99, 0x6e, 0xb, 0xd1, 0xe3, 0x40, 0xb, 0x1e, 0xe7, 0xe7, 0x35, 0xf1, 0xe1, 0xba}}
+	return a, nil
+}
+
+var _get_band_idolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\x8e\xd1\x4a\xc3\x30\x14\x86\xef\xf3\x14\xff\x85\xd0\x0d\xd6\x80\x0f\xb0\x81\xd6\x0c\x27\xb5\x91\x6c\x20\x5e\x8d\xac\x39\x6c\x07\x4d\x1b\x7a\x8a\xe2\xdb\x4b\x33\xa5\xde\x85\xfc\x87\xef\xfb\xca\x12\x2f\x03\x47\x3f\x7c\xe3\xe4\xbb\x00\x16\xb4\x17\x6a\xdf\x29\x60\xec\x7b\x70\x87\xd6\x0b\x81\x47\x41\xa4\x78\xa2\x41\x2e\x9c\xa6\xab\xc8\x22\xdc\x9d\xb5\xda\x9b\xda\x54\x07\xb0\xe6\xb0\x02\xeb\x89\x72\xbc\x3e\x83\x1f\xfd\x0a\x95\xbd\xab\xcd\xbe\x32\x8b\xa4\x39\xfa\x33\xe5\xb1\x28\x96\x6a\xeb\xec\x33\x38\xf4\x1f\x02\x56\xb5\xd9\x1e\xf0\x64\x77\x4d\xfe\x39\xa6\x81\x3e\x99\xbe\x04\x09\xb6\x41\xd2\x1c\xb0\xce\x0a\xf5\xfa\x68\x9c\x99\x3d\x58\xe3\xe6\x56\x01\xd6\xe5\x19\xbb\x06\x8b\xbf\xa2\x09\xc4\x01\xd9\x33\xc7\x0b\xae\x88\xff\x80\xa5\xb2\xee\xc1\x38\xdc\xbf\xfd\x66\x97\x9b\x4d\xd1\xf9\x48\x85\xfa\x19\x00\x30\xd8\x22\xc8\x21\x01\x00\x00")
+
+func get_band_idolsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_band_idolsSql,
+		"get_band_idols.sql",
+	)
+}
+
+func get_band_idolsSql() (*asset, error) {
+	bytes, err := get_band_idolsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_band_idols.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xde, 0xd7, 0x21, 0x7d, 0x36, 0x37, 0xcd, 0x4f, 0xe7, 0x72, 0xe3, 0x4e, 0x43, 0x41, 0x69, 0xed, 0x4, 0xec, 0xb1, 0x34, 0x4, 0x66, 0x57, 0x77, 0x32, 0xfd, 0xa2, 0xda, 0x50, 0x7a, 0x18, 0x12}}
+	return a, nil
+}
+
+var _get_bandsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x2a\x00\xd5\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x2c\x20\x64\x61\x74\x61\x20\x46\x52\x4f\x4d\x20\x62\x61\x6e\x64\x73\x20\x57\x48\x45\x52\x45\x20\x72\x65\x76\x20\x3e\x20\x24\x31\x0a\x03\x00\x27\xef\xc8\x55\x2a\x00\x00\x00")
 
 func get_bandsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -89,11 +209,91 @@ func get_bandsSql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "get_bands.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xce, 0xf3, 0x28, 0xfe, 0x9b, 0x8b, 0x77, 0x51, 0x29, 0x48, 0xac, 0xb1, 0xe6, 0x2e, 0x6a, 0xe4, 0xa7, 0x94, 0x74, 0xeb, 0xc4, 0x3b, 0x95, 0xf, 0x22, 0xa7, 0x3c, 0xd8, 0x26, 0xf4, 0xe8, 0xa2}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd3, 0x1d, 0xd9, 0x44, 0xf6, 0x6c, 0xba, 0xf9, 0x5b, 0x45, 0xcb, 0xec, 0x44, 0x4, 0xef, 0xbb, 0x7e, 0xba, 0x50, 0xde, 0x2c, 0xe3, 0xb7, 0x1a, 0xa4, 0x84, 0x79, 0xfd, 0x99, 0xb3, 0x21, 0x83}}
 	return a, nil
 }
 
-var _get_idol_previewsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0a\x76\xf5\x71\x75\x0e\x51\xc8\x4c\xd1\x51\xc8\xcc\x4d\x4c\x4f\x8d\xcf\x4c\x51\x70\x0b\xf2\xf7\x55\xc8\x4c\xc9\xcf\x89\x2f\x28\x4a\x2d\xcb\x4c\x2d\x2f\xe6\x02\x04\x00\x00\xff\xff\xb1\xe8\x17\xc4\x27\x00\x00\x00")
+var _get_deletionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3b\x00\xc4\xff\x53\x45\x4c\x45\x43\x54\x20\x6b\x69\x6e\x64\x2c\x20\x69\x64\x20\x46\x52\x4f\x4d\x20\x64\x65\x6c\x65\x74\x69\x6f\x6e\x73\x0a\x57\x48\x45\x52\x45\x20\x72\x65\x76\x20\x3e\x20\x24\x31\x0a\x4f\x52\x44\x45\x52\x20\x42\x59\x20\x72\x65\x76\x0a\x03\x00\x85\x7e\x7b\x5e\x3b\x00\x00\x00")
+
+func get_deletionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_deletionsSql,
+		"get_deletions.sql",
+	)
+}
+
+func get_deletionsSql() (*asset, error) {
+	bytes, err := get_deletionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_deletions.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc2, 0xea, 0xfa, 0xd1, 0x93, 0xb2, 0x2d, 0xd, 0x5c, 0x27, 0x8a, 0x2e, 0xf8, 0xe6, 0x61, 0x65, 0xa, 0xe6, 0xe6, 0xa6, 0x83, 0x65, 0x89, 0xba, 0xba, 0xe3, 0xe4, 0xb4, 0x6c, 0x6f, 0x27, 0x9c}}
+	return a, nil
+}
+
+var _get_facesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5a\x00\xa5\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x2c\x20\x69\x6d\x61\x67\x65\x5f\x69\x64\x2c\x20\x69\x64\x6f\x6c\x5f\x69\x64\x2c\x20\x64\x65\x73\x63\x72\x69\x70\x74\x6f\x72\x20\x46\x52\x4f\x4d\x20\x66\x61\x63\x65\x73\x0a\x57\x48\x45\x52\x45\x20\x69\x64\x6f\x6c\x5f\x63\x6f\x6e\x66\x69\x72\x6d\x65\x64\x20\x3d\x20\x24\x31\x0a\x4f\x52\x44\x45\x52\x20\x42\x59\x20\x69\x64\x0a\x03\x00\x13\x7c\x2d\x72\x5a\x00\x00\x00")
+
+func get_facesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_facesSql,
+		"get_faces.sql",
+	)
+}
+
+func get_facesSql() (*asset, error) {
+	bytes, err := get_facesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_faces.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x54, 0xb7, 0xf0, 0x91, 0xb, 0xe3, 0x9f, 0x42, 0x20, 0x40, 0x9c, 0xff, 0x2b, 0xad, 0x3, 0x1e, 0x6c, 0x66, 0xdf, 0x29, 0x29, 0xd8, 0xdd, 0x27, 0x6, 0xf9, 0xbf, 0x71, 0xa3, 0x2, 0x45, 0x53}}
+	return a, nil
+}
+
+var _get_idolSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x79\x00\x86\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x2e\x62\x61\x6e\x64\x5f\x69\x64\x2c\x20\x69\x2e\x64\x61\x74\x61\x2c\x20\x43\x4f\x41\x4c\x45\x53\x43\x45\x28\x70\x2e\x69\x6d\x61\x67\x65\x5f\x69\x64\x2c\x20\x27\x27\x29\x0a\x46\x52\x4f\x4d\x20\x69\x64\x6f\x6c\x73\x20\x69\x0a\x4c\x45\x46\x54\x20\x4a\x4f\x49\x4e\x20\x69\x64\x6f\x6c\x5f\x70\x72\x65\x76\x69\x65\x77\x73\x20\x70\x20\x4f\x4e\x20\x70\x2e\x69\x64\x20\x3d\x20\x69\x2e\x69\x64\x0a\x57\x48\x45\x52\x45\x20\x69\x2e\x69\x64\x20\x3d\x20\x24\x31\x0a\x03\x00\xae\x37\x3b\x31\x79\x00\x00\x00")
+
+func get_idolSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_idolSql,
+		"get_idol.sql",
+	)
+}
+
+func get_idolSql() (*asset, error) {
+	bytes, err := get_idolSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_idol.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1c, 0x3a, 0xc0, 0xe8, 0x86, 0x2d, 0xa7, 0xd6, 0x75, 0x82, 0x79, 0xa6, 0x2b, 0xe3, 0x62, 0x58, 0x45, 0x1d, 0x2f, 0xa6, 0x4, 0xc6, 0x6e, 0xc9, 0x6d, 0x4c, 0x67, 0x4d, 0xc1, 0x85, 0x74, 0x28}}
+	return a, nil
+}
+
+var _get_idol_existsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x32\x00\xcd\xff\x53\x45\x4c\x45\x43\x54\x20\x45\x58\x49\x53\x54\x53\x20\x28\x53\x45\x4c\x45\x43\x54\x20\x31\x20\x46\x52\x4f\x4d\x20\x69\x64\x6f\x6c\x73\x20\x57\x48\x45\x52\x45\x20\x69\x64\x20\x3d\x20\x24\x31\x29\x0a\x03\x00\x77\x87\x92\xcd\x32\x00\x00\x00")
+
+func get_idol_existsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_idol_existsSql,
+		"get_idol_exists.sql",
+	)
+}
+
+func get_idol_existsSql() (*asset, error) {
+	bytes, err := get_idol_existsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_idol_exists.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6d, 0xf6, 0x97, 0x78, 0xd4, 0x12, 0x52, 0xfd, 0xc0, 0xf3, 0x9b, 0x3, 0x38, 0x63, 0x76, 0xc7, 0xa4, 0x94, 0xfc, 0x2d, 0xc4, 0x94, 0x7c, 0x9c, 0x4, 0x96, 0x54, 0xee, 0xe3, 0xde, 0xe6, 0x98}}
+	return a, nil
+}
+
+var _get_idol_previewsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x27\x00\xd8\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x2c\x20\x69\x6d\x61\x67\x65\x5f\x69\x64\x20\x46\x52\x4f\x4d\x20\x69\x64\x6f\x6c\x5f\x70\x72\x65\x76\x69\x65\x77\x73\x0a\x03\x00\xb1\xe8\x17\xc4\x27\x00\x00\x00")
 
 func get_idol_previewsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -113,7 +313,7 @@ func get_idol_previewsSql() (*asset, error) {
 	return a, nil
 }
 
-var _get_idolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0a\x76\xf5\x71\x75\x0e\x51\xc8\x4c\xd1\x51\x48\x4a\xcc\x4b\x89\x07\x31\x52\x12\x4b\x12\x15\xdc\x82\xfc\x7d\x15\x32\x53\xf2\x73\x8a\xb9\x00\x01\x00\x00\xff\xff\xd9\x11\x11\x34\x24\x00\x00\x00")
+var _get_idolsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x33\x00\xcc\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x2c\x20\x62\x61\x6e\x64\x5f\x69\x64\x2c\x20\x64\x61\x74\x61\x20\x46\x52\x4f\x4d\x20\x69\x64\x6f\x6c\x73\x20\x57\x48\x45\x52\x45\x20\x72\x65\x76\x20\x3e\x20\x24\x31\x0a\x03\x00\x35\x30\x4d\x91\x33\x00\x00\x00")
 
 func get_idolsSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -129,11 +329,91 @@ func get_idolsSql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "get_idols.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x38, 0x44, 0xfe, 0xcc, 0x62, 0x3d, 0x28, 0x78, 0xf2, 0xd7, 0x53, 0xfa, 0x71, 0xf0, 0xe5, 0xcb, 0x5c, 0xa8, 0xd2, 0xf, 0x89, 0xcb, 0x9, 0xa4, 0x8f, 0xcb, 0x78, 0xdd, 0x79, 0xfd, 0x28, 0xa9}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x5, 0x8d, 0x2, 0x80, 0x68, 0x60, 0xae, 0xf6, 0xf3, 0x6f, 0x5e, 0xae, 0xa4, 0xd7, 0x9a, 0x79, 0xdd, 0xea, 0x73, 0x12, 0x89, 0x8c, 0x24, 0x89, 0xe1, 0x30, 0x77, 0x4e, 0x49, 0xcd, 0x77, 0x80}}
+	return a, nil
+}
+
+var _get_image_existsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x35\x00\xca\xff\x53\x45\x4c\x45\x43\x54\x20\x45\x58\x49\x53\x54\x53\x20\x28\x53\x45\x4c\x45\x43\x54\x20\x31\x20\x46\x52\x4f\x4d\x20\x69\x6d\x61\x67\x65\x73\x20\x57\x48\x45\x52\x45\x20\x73\x68\x61\x31\x20\x3d\x20\x24\x31\x29\x0a\x03\x00\xf7\x44\x19\xa0\x35\x00\x00\x00")
+
+func get_image_existsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_image_existsSql,
+		"get_image_exists.sql",
+	)
+}
+
+func get_image_existsSql() (*asset, error) {
+	bytes, err := get_image_existsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_image_exists.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd5, 0x4, 0xd9, 0x18, 0xe, 0xde, 0x2, 0x98, 0xfe, 0xdb, 0x15, 0xe2, 0xda, 0xb3, 0xa7, 0xa3, 0x2e, 0xed, 0xeb, 0x38, 0x70, 0x18, 0xca, 0x58, 0x4b, 0x1c, 0x1f, 0x2f, 0x31, 0x89, 0x7d, 0x68}}
 	return a, nil
 }
 
-var _get_train_dataSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x0a\x76\xf5\x71\x75\x0e\x51\xc8\x4c\xc9\xcf\x89\xcf\x4c\xd1\x51\x48\x49\x2d\x4e\x2e\xca\x2c\x28\xc9\x2f\x52\x70\x0b\xf2\xf7\x55\x48\x4b\x4c\x4e\x2d\xe6\x0a\xf7\x70\x0d\x72\x85\x28\x4a\xce\xcf\x4b\xcb\x2c\xca\x4d\x4d\x51\xb0\x55\x08\x09\x0a\x75\xe5\xf2\x0f\x72\x71\x0d\x52\x70\x8a\x84\x99\xc1\x05\x08\x00\x00\xff\xff\x24\x9f\xe9\xe0\x53\x00\x00\x00")
+var _get_label_conflictsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x8f\xcf\x6a\xf3\x30\x10\xc4\xef\x7a\x8a\x39\x04\x3e\x1b\x3e\x1b\xd2\x63\x69\x0a\x69\xa3\xd0\x42\x9a\x80\x1c\x68\x73\x0a\x8a\xb5\xaa\x05\x8a\x97\x58\x36\xa5\x6f\x5f\x24\xa7\xff\x08\xbd\x49\xb3\x33\xbf\x9d\x2d\x0a\x28\x3a\x0d\x14\x7a\x32\xb0\xba\xa6\x80\xb7\xc6\xd5\x0d\x6a\xdd\xfe\xeb\x71\x20\x78\x7d\x20\xef\xc9\x40\x07\xf4\x0d\xc1\x19\xf6\x38\x50\xad\x87\x40\x60\x8b\xa1\x75\xa7\x81\x44\x51\x20\x73\x47\xfd\x4a\x7b\x67\xfe\x27\xd3\xde\x99\xfc\x3a\xbd\xa0\x7d\x47\xda\xbc\xa3\xd1\x01\xba\xe5\xbe\xa1\x2e\x2d\x03\xb7\x89\x19\xf4\x91\x90\xd2\x91\xc3\xdd\x97\xa9\xfb\xd5\x0d\x2e\x5c\x26\x4a\x51\xc9\x95\xbc\xdf\xc2\x96\xce\x60\xa9\x36\x4f\xe7\x3b\xac\x78\x7e\x90\x4a\x8e\xfa\x0c\xf3\xf5\x2e\x9b\x5c\xe5\x98\xaf\x17\x49\x62\xbf\xaf\xb9\xb5\xae\x3b\x52\x1c\x2f\xe7\xab\x4a\x0a\xa4\xb1\x7c\x79\xac\xb6\x15\x32\x01\x00\x67\xfa\xf4\x27\x9a\xd3\x64\xc4\x73\xf9\x79\x36\x66\xb0\xdf\x9f\x08\xe2\xb8\xfa\xe6\x36\xca\x26\x45\x46\x7e\x16\x75\xf6\xd1\x35\xc3\x64\x8a\x8d\x42\xc6\x97\x2d\xf9\x8f\x96\x79\x9e\x8b\x8d\x5a\x48\x85\xbb\x1d\x6c\xe9\x8c\xf8\x18\x00\x0c\x32\x7f\xe5\xc6\x01\x00\x00")
+
+func get_label_conflictsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_label_conflictsSql,
+		"get_label_conflicts.sql",
+	)
+}
+
+func get_label_conflictsSql() (*asset, error) {
+	bytes, err := get_label_conflictsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_label_conflicts.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf4, 0xea, 0xf3, 0x80, 0x5b, 0x9f, 0xba, 0x6f, 0x7c, 0xa5, 0xb2, 0x94, 0x49, 0xd9, 0x26, 0xee, 0x40, 0x6a, 0x49, 0x42, 0x63, 0x92, 0x41, 0x0, 0x96, 0xdb, 0x8c, 0xe3, 0x5, 0xb, 0xad, 0x52}}
+	return a, nil
+}
+
+var _get_membershipsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x84\x8f\xd1\x4a\x87\x30\x14\x87\xef\xf7\x14\xbf\x8b\xc0\xff\x1f\x74\xd0\x03\x14\x94\x4e\x32\xa6\x83\xcd\x88\x5d\xc9\x6c\x03\x07\x4e\x63\x1b\x3d\x7f\x58\x90\x74\x11\x9d\xab\x03\xe7\x7c\xf0\x7d\x8a\x71\x56\x8f\x08\xd4\xdb\x7d\x9d\xbc\x2d\x11\xe8\x6c\x36\xfb\xb5\xd6\xe2\x81\x33\x55\xb3\x4b\xa0\x71\x5f\x5d\x89\xa2\xb8\x96\x04\xdf\xf3\x73\xcc\xfb\xf4\xb6\x98\x78\x09\x34\x65\x13\xf3\x64\x4d\x3e\x5e\xb5\xd6\xba\xea\xfb\xaa\x69\x8a\xeb\xbf\xa4\xdb\xec\x9f\x1c\x69\xa5\xe8\x11\x5c\x98\x5d\x4c\x8b\x7f\x4f\x08\xe4\x59\x74\x03\x0e\xe5\x04\x0f\x31\xc0\x53\x6f\x71\x77\x66\x90\xd7\x27\x26\x19\x3c\x8d\xee\x03\xf7\xb8\xb9\x25\x42\x36\x4c\xe2\x51\xff\x4e\x3d\x8d\x31\xbc\x70\xae\xd0\x76\x52\x8d\xe4\x73\x00\x48\xe3\x73\xd6\x16\x01\x00\x00")
+
+func get_membershipsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_membershipsSql,
+		"get_memberships.sql",
+	)
+}
+
+func get_membershipsSql() (*asset, error) {
+	bytes, err := get_membershipsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_memberships.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6b, 0xa6, 0x22, 0xdb, 0xdf, 0xfa, 0x2, 0x36, 0x1b, 0x42, 0xdb, 0x96, 0x5b, 0x5, 0x72, 0x42, 0x67, 0x6c, 0x2b, 0x34, 0x63, 0x4e, 0xaf, 0x89, 0x35, 0x90, 0x9b, 0x2a, 0x3b, 0x8, 0x7d, 0xab}}
+	return a, nil
+}
+
+var _get_revisionSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8e\xc1\x4a\xc4\x40\x10\x44\xef\xf9\x8a\xba\xb9\x0b\xce\x82\x9f\x20\xba\x07\xc1\x93\x7a\x5f\x26\x93\xde\x4c\xc3\xa4\x47\xba\x7b\x9d\xe4\xef\x65\x82\x0a\xde\x9a\x2e\xea\xd5\x0b\x01\x6f\xf4\xc5\xc6\x55\x0c\x51\x09\x2f\xcf\x86\x7a\x45\x53\x76\x96\x19\xae\x51\x2c\x26\xdf\xf3\x96\x39\x65\xa4\xba\x2c\xec\x60\x41\xd4\x91\x5d\xa3\x6e\x43\x08\xa8\x3a\x91\x9e\xf0\x58\xca\xff\xd2\x48\xa5\x36\x78\x26\x98\xc4\x4f\xcb\xd5\xef\x0c\xeb\xb2\xd7\x09\x57\x16\xb6\x4c\xd3\x3d\xac\x76\x4a\xca\x51\x66\x32\x34\xf6\x8c\x59\x29\x3a\x29\xf4\xc7\x10\x52\x1d\xfd\x1c\x0b\x61\x23\x47\xe3\x52\x30\x12\x94\xfc\xa6\x42\x13\xaa\xf4\xa5\x0e\x12\x5a\x1d\xb6\x49\x3a\x0d\xef\xe7\xd7\xf3\xd3\x07\x7c\xe5\xe9\xf2\xeb\x70\xe9\x06\x87\xfd\x95\x6e\xaa\x24\xfe\x17\x1d\x8e\x47\x04\x3c\x0c\xdf\x03\x00\xa9\x24\x2c\x34\x1b\x01\x00\x00")
+
+func get_revisionSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_get_revisionSql,
+		"get_revision.sql",
+	)
+}
+
+func get_revisionSql() (*asset, error) {
+	bytes, err := get_revisionSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "get_revision.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x62, 0xbd, 0x78, 0x2, 0x32, 0xc6, 0xbf, 0xa7, 0x1c, 0x18, 0xcd, 0xac, 0x3a, 0x3b, 0xf1, 0xc9, 0xa9, 0xba, 0x84, 0xc9, 0x7, 0xb3, 0x3d, 0x2b, 0x35, 0x9, 0xa7, 0x8, 0x0, 0x24, 0x2a, 0x3f}}
+	return a, nil
+}
+
+var _get_train_dataSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x53\x00\xac\xff\x53\x45\x4c\x45\x43\x54\x20\x69\x64\x6f\x6c\x5f\x69\x64\x2c\x20\x64\x65\x73\x63\x72\x69\x70\x74\x6f\x72\x20\x46\x52\x4f\x4d\x20\x66\x61\x63\x65\x73\x0a\x57\x48\x45\x52\x45\x20\x69\x64\x6f\x6c\x5f\x63\x6f\x6e\x66\x69\x72\x6d\x65\x64\x20\x3d\x20\x54\x52\x55\x45\x0a\x4f\x52\x44\x45\x52\x20\x42\x59\x20\x69\x64\x6f\x6c\x5f\x69\x64\x0a\x03\x00\x24\x9f\xe9\xe0\x53\x00\x00\x00")
 
 func get_train_dataSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -153,7 +433,7 @@ func get_train_dataSql() (*asset, error) {
 	return a, nil
 }
 
-var _init_dbSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x93\x51\x6f\x9b\x30\x10\xc7\xdf\xf9\x14\xb7\xa7\x80\xd4\x56\xcd\xb4\xbd\xac\x9a\x26\x06\x8e\x86\xc2\x48\x47\x88\x44\x9f\xa2\xc3\xbe\x10\x4f\xc4\x8e\x8c\xd3\x75\xdf\x7e\x82\xc4\x25\xad\x52\x34\xf5\x0d\x1d\x3e\xfc\xfb\xff\xee\x88\x72\x16\x16\x0c\x8a\xf0\x7b\xca\x20\x99\x41\xb6\x28\x80\x95\xc9\xb2\x58\x42\x85\x4a\xb4\xe0\x7b\x00\x52\xc0\xe1\x20\x05\xdc\xe7\xc9\xcf\x30\x7f\x80\x39\x7b\xb8\xf2\x00\x04\x5a\x84\xdf\xad\x56\x55\xdf\x96\xad\xd2\xb4\x2b\x47\x3f\x58\x34\x07\x3f\x5b\x14\x7e\x7f\xe2\x1b\x4c\xa4\x98\x04\x10\x66\x31\xb8\x82\xc2\x1d\x4d\x02\x2f\xb8\xf3\xbc\x11\x02\x29\x74\x33\x4a\xd0\x21\xae\xdd\x3b\xc7\x00\x39\x9b\xb1\x9c\x65\x11\x73\x19\x16\x19\xc4\x2c\x65\x05\x83\x28\x5c\x46\x61\xcc\xde\x49\x7f\x5e\x3c\x5d\xfd\x76\xae\xeb\x6b\x28\xcb\xd2\x9f\x63\x8d\x3b\x19\x7c\x81\x7b\x34\x16\xf4\x06\xf8\xc1\x12\xdf\xa2\xba\x19\x4d\xbe\xc3\x9a\x8e\xd1\xdb\x2d\x4e\x81\x6f\xd1\xf8\x9f\x6e\x83\x73\x01\xff\x63\x6f\xbd\x37\xf4\x28\xe9\xcf\x9b\x16\xcf\x65\x1d\x75\x5f\x94\xd5\xf3\x74\xa6\x9f\x41\x56\x59\xf2\x6b\xc5\x2e\x4a\x3f\xc2\x3b\x09\xb1\x56\x13\x0b\x86\x36\x64\x48\x71\x72\xd1\xac\x86\x8a\x00\xab\x86\xba\xc7\xbd\xa1\x46\xa3\x00\x41\x2d\x37\x72\x6f\xb5\x69\x6f\xba\xe6\x62\x11\x2f\x06\x85\x89\x12\xf4\x44\xed\x87\xb1\xd4\x1b\xe4\xf4\x9c\xb6\x92\x75\x4b\x46\x62\xf3\x7a\x71\x0c\x71\x8b\xaa\x6e\x08\x2a\xfd\xf4\x62\xfe\x03\x01\x54\x7f\x2d\xe1\x90\xf0\xb4\x19\x9a\x5b\xb2\xeb\x86\x54\x6d\xb7\xfe\x70\x3a\x80\xaf\xf0\x79\xfa\x31\xb8\x6c\xeb\xfc\x86\x7e\x2e\x63\x4b\x3b\x36\x87\xae\x97\x6b\xb5\x91\x66\x47\x02\x2a\xad\x1b\x42\x35\x7c\x25\x66\xb3\x70\x95\x16\x30\x0b\xd3\x65\xdf\xd0\xea\x83\xe1\x04\x8f\x68\x7a\x96\xe9\xed\x2b\x98\xd3\x18\x7d\x87\x7c\xe5\xf0\x5e\xfc\x9c\x49\x16\xb3\xf2\x92\xe8\xb5\x0b\xa3\x95\x33\xef\xfa\xef\xbc\x7f\x01\x00\x00\xff\xff\x78\xa0\x3d\xa6\x5c\x04\x00\x00")
+var _init_dbSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x56\x4d\x6f\xe3\x36\x10\xbd\xfb\x57\x4c\x4f\x96\x0a\xdb\xdd\x04\xbb\x7b\x68\xb0\x2d\x54\x4b\xc1\x0a\xf1\xda\xa9\xed\x00\x59\x14\x85\x40\x89\x23\x89\x6b\x89\x54\x49\xda\x8e\xfb\xeb\x0b\x4a\xa2\x2d\xe7\x43\x49\xb7\x97\x1e\x3d\x9e\x21\xdf\x7b\xf3\x66\xc4\xe9\x32\xf0\xd6\x01\xac\xbd\xdf\x66\x01\x84\xd7\x30\x5f\xac\x21\xb8\x0f\x57\xeb\x15\xc4\x84\x53\x05\xce\x00\x80\x51\xd8\x6e\x19\x85\xdb\x65\xf8\xc5\x5b\x7e\x85\x9b\xe0\xeb\x68\x00\x40\x89\x26\xf0\x4d\x09\x1e\xd7\x65\xf3\xbb\xd9\xcc\x84\xa7\x9f\x83\xe9\x0d\x38\xf3\xc5\xda\xa9\x33\x7e\x85\x21\xa3\x43\x17\xbc\xb9\x0f\x36\xc0\x49\x89\x43\x77\xe0\x5e\x0d\x06\x3d\x08\x18\x15\x45\x2f\x02\x03\x31\xb2\xff\x59\x0c\xb0\x0c\xae\x83\x65\x30\x9f\x06\x96\xc3\x62\x0e\x7e\x30\x0b\xd6\x01\x4c\xbd\xd5\xd4\xf3\x83\xef\x44\xdf\x0d\xb6\x57\xbf\xcc\x6b\x3c\x86\xfb\xfb\x7b\xe7\x86\x64\xa4\x64\xee\xcf\x70\x4b\xa4\x06\x91\x42\xb2\xd5\x98\xe4\x84\x4f\x7a\x99\x97\x24\xc3\x86\xba\xca\xc9\x05\x24\x39\x91\xce\xfb\x77\x6e\x57\x80\xb7\xa8\x17\x55\x12\x77\x0c\xf7\x2f\xaa\xd8\x15\xcb\x14\xbc\x20\x56\x8d\xc7\x28\x7d\x04\x72\x37\x0f\x7f\xbf\x0b\x9e\x15\xbd\x4e\x56\x56\x04\x5f\xf0\xa1\x06\x89\x29\x4a\xe4\x09\x5a\x6a\x5a\x40\x8c\x40\xe2\x02\x41\x0b\xa8\x24\x16\x82\x50\xa0\xa8\x12\xc9\x2a\x2d\xa4\x9a\x98\xe2\xf5\xc2\x5f\x9c\x24\x0c\x39\xc5\x07\x54\x3f\xf4\xb1\x4e\x49\xd2\x0a\xc7\x28\xc4\x2c\x53\x28\x19\x29\xba\x94\x4d\xf3\x25\x26\x9a\xf0\xac\x40\x88\xc5\xc3\x59\xff\x4f\x08\x20\x3e\x68\x24\x27\x86\xad\x33\x44\xa2\x51\x47\x05\xf2\x4c\xe7\xce\x29\xdb\x85\x4f\xf0\xe1\xe2\xd2\x7d\x5e\xad\xee\x0d\x46\xe6\x5e\xd3\xf6\xf5\xc1\xd4\x26\x82\xa7\x4c\x96\x48\x21\x16\xa2\x40\xc2\x4f\xa7\xf8\xc1\xb5\x77\x37\x5b\xc3\xb5\x37\x5b\xd5\x05\x4a\x6c\x65\x82\xb0\x23\xb2\xee\xdc\xc5\xbb\x47\x60\xda\x36\x3a\x16\xf2\xc8\xc2\x3b\x1b\xce\x70\xee\x07\xf7\xcf\x09\x1d\x59\x32\x82\x5b\xe5\x6d\x7d\xd3\xfd\x90\x8a\x02\x12\xc2\xeb\x66\x43\x89\x65\x8c\xd2\x4c\x81\xc2\x1d\x4a\x52\xd8\x25\xa3\xb6\xf1\x78\xcb\x99\x56\x23\xa8\xa4\xf8\x86\x89\x86\x4c\x8a\x6d\xa5\x46\xe6\x10\x2d\x09\x57\x29\x4a\xe5\x4e\x6a\x78\x6a\x62\x07\x9f\x29\xd8\x60\xa5\x81\x28\xd0\x39\x42\x25\x59\x49\xe4\xa1\x3e\x15\xf6\x39\x4b\x72\x60\x0a\x48\xb1\x27\x07\x65\x0e\xb2\x08\x54\xce\xaa\x11\x28\x44\x48\x79\x74\x0a\xa9\x89\xfa\xab\xe8\x9d\xcb\x4e\x2e\x38\xff\xb1\x97\x96\xc3\xf7\x2c\x2f\x29\x8a\xf3\xa6\x9a\xa0\xd2\x44\xea\x88\x12\x8d\x66\x1f\xa1\x09\x21\xa7\xe7\x81\xee\xe8\xdb\x56\x8d\x2c\x14\xb7\xb3\x00\x8f\x95\xe1\xaa\x01\xb6\x58\x76\x2f\xe8\x44\x8f\x99\xbf\x7c\xea\x64\xbc\xea\x9f\x8e\x94\x91\x95\x42\xf0\x4e\x83\x14\x38\x16\x57\xe3\xa5\x25\xee\x98\x62\x82\x2b\x48\x85\x04\xc6\x13\x89\x25\x72\x4d\x0a\xe3\x99\x94\x15\x08\xea\xc0\x93\x09\x04\x3b\x6c\x3d\xf0\x93\x61\x68\xa6\x90\x67\x08\x19\x6a\x05\xa1\x6f\x4e\x12\x69\x6d\x97\xbd\x64\x9a\xf1\xac\xf1\x17\x49\x34\x13\xdc\x38\x49\xb6\xf7\x8c\x80\x62\x81\x1a\x29\x20\xd7\x4c\x33\x54\x40\x24\x82\xc4\x44\x48\x8a\x14\xb4\x30\x67\x75\xb6\x98\xc6\xa2\x80\xa4\x60\xc8\xb5\x02\x12\x8b\xad\x36\xd7\x94\x13\x58\x35\x4e\x6b\x61\x46\x12\x77\xc6\x69\x35\x0d\x2d\x59\x96\xa1\x6c\xec\xc9\xa9\x41\x19\x59\x00\xc7\xa4\x7d\x7e\x38\x03\x19\xfa\x6a\x32\xf0\x66\xeb\x60\xd9\xba\xd4\x08\xa5\x06\x00\x9e\xef\xc3\x74\x31\xbb\xfb\x32\x7f\xa4\xb6\xc4\x9d\xd9\x86\x8c\xeb\xa7\xcb\x42\x3f\x30\x1a\x25\x5b\x29\x91\x6b\xc7\x1d\xf5\x1d\xb3\xad\x4c\xa7\x69\x44\x34\x68\x56\xa2\xd2\xa4\xac\xf4\xdf\x4f\x8f\xe4\x62\xef\x98\xfe\x77\x31\x9a\x5e\xfc\x1f\x31\xf6\x78\xb4\x96\xd5\xb4\x03\x04\xb7\xab\x4a\xe2\xce\xbd\xea\x2b\xaa\x79\xda\xa2\xf6\x09\xd3\x14\xf5\x6d\x96\xda\x6a\xb5\xb9\xcd\x5e\xf9\x77\x52\x6c\x18\xa7\x9d\x6d\xe0\x3e\xf9\x66\xd5\x09\xe1\x1c\x9c\xa1\x21\x31\x1c\x99\x37\x8d\x28\x86\x6e\x2d\xe4\xe3\x15\x64\x62\xad\xf1\xdf\xa6\xe1\x6b\x93\x7e\xa4\x66\x45\xe9\x70\x6d\x85\x19\x8f\xc1\xbb\x0d\xed\xec\x4c\x60\xc1\x8b\x03\xac\x3e\x7b\xe3\xcb\x0f\x1f\xed\xb4\x6e\xf0\x60\xb6\xb9\xd2\x42\x22\x1d\x19\xb7\x88\x0d\x52\xd8\xe0\xa1\x99\x4b\xf3\x21\x30\x53\x64\xa6\xea\xf8\xdc\xe8\xdd\xe6\xa4\x62\x51\x5d\xfe\xda\x73\x61\x83\x87\x28\x27\x2a\x37\xab\x44\x3a\x1f\xdf\x3f\x79\xfe\x98\x24\xf3\xa6\x3d\xdb\xc9\x67\xff\xaa\x44\x54\xa8\x8e\xff\x5f\xbe\x73\xff\xf8\xf3\x2c\x21\x91\xf8\x76\xdb\x9a\xfb\x5a\x01\x1e\x15\xd8\x67\x97\x57\x55\x05\x43\xb3\x52\x71\x2c\xd2\x14\x4a\x96\x49\x52\x8b\xde\x7c\xf8\x9a\xdf\x18\xfd\xf8\xfa\x37\xef\x58\x69\x9f\x55\x67\x24\x1f\x49\x45\x9a\x7b\xdf\x46\x63\xe0\x5e\x0d\xfe\x19\x00\x72\x0c\x26\x09\x82\x0c\x00\x00")
 
 func init_dbSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -169,7 +449,147 @@ func init_dbSql() (*asset, error) {
 	}
 
 	info := bindataFileInfo{name: "init_db.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb8, 0xca, 0x29, 0x79, 0x39, 0x68, 0xd3, 0x47, 0x95, 0x36, 0x3, 0x5, 0xac, 0x5c, 0xc9, 0xf4, 0xe3, 0x35, 0x34, 0x9c, 0x4c, 0x0, 0x7f, 0x8e, 0x22, 0xc7, 0xb7, 0x53, 0x40, 0x3d, 0x9b, 0x9f}}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1a, 0x63, 0x83, 0xa5, 0x21, 0x9f, 0x63, 0x1b, 0x41, 0x2, 0x39, 0x80, 0x9f, 0xf7, 0x87, 0x3e, 0xd9, 0x32, 0xfc, 0x1f, 0xa0, 0x51, 0x2f, 0xc9, 0xda, 0xa5, 0xee, 0x2a, 0x7c, 0xa6, 0x9a, 0x95}}
+	return a, nil
+}
+
+var _insert_api_keySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5b\x00\xa4\xff\x49\x4e\x53\x45\x52\x54\x20\x49\x4e\x54\x4f\x20\x61\x70\x69\x5f\x6b\x65\x79\x73\x20\x28\x6b\x65\x79\x5f\x68\x61\x73\x68\x2c\x20\x6e\x61\x6d\x65\x2c\x20\x73\x63\x6f\x70\x65\x73\x29\x20\x56\x41\x4c\x55\x45\x53\x20\x28\x24\x31\x2c\x20\x24\x32\x2c\x20\x24\x33\x29\x0a\x52\x45\x54\x55\x52\x4e\x49\x4e\x47\x20\x69\x64\x2c\x20\x63\x72\x65\x61\x74\x65\x64\x5f\x61\x74\x0a\x03\x00\xe0\x2e\xbd\x7e\x5b\x00\x00\x00")
+
+func insert_api_keySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_insert_api_keySql,
+		"insert_api_key.sql",
+	)
+}
+
+func insert_api_keySql() (*asset, error) {
+	bytes, err := insert_api_keySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "insert_api_key.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x44, 0x8, 0xa, 0x2a, 0x45, 0xeb, 0x94, 0x5, 0xda, 0x74, 0x19, 0xba, 0x6f, 0x20, 0x9d, 0x98, 0x34, 0x75, 0xe2, 0x6, 0x8b, 0xa3, 0x6f, 0x3f, 0xab, 0xaa, 0xab, 0x59, 0x7a, 0x3e, 0x9b, 0x27}}
+	return a, nil
+}
+
+var _insert_idolSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3a\x00\xc5\xff\x49\x4e\x53\x45\x52\x54\x20\x49\x4e\x54\x4f\x20\x69\x64\x6f\x6c\x73\x20\x28\x69\x64\x2c\x20\x62\x61\x6e\x64\x5f\x69\x64\x2c\x20\x64\x61\x74\x61\x29\x20\x56\x41\x4c\x55\x45\x53\x20\x28\x24\x31\x2c\x20\x24\x32\x2c\x20\x24\x33\x29\x0a\x03\x00\x3a\xbe\x50\xa3\x3a\x00\x00\x00")
+
+func insert_idolSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_insert_idolSql,
+		"insert_idol.sql",
+	)
+}
+
+func insert_idolSql() (*asset, error) {
+	bytes, err := insert_idolSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "insert_idol.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8c, 0x2e, 0x88, 0x70, 0x5a, 0x40, 0xaa, 0xd2, 0x24, 0x7b, 0xe, 0xb8, 0x19, 0xe6, 0xdc, 0x51, 0x62, 0xc, 0xb1, 0xdf, 0xad, 0xa9, 0xb9, 0x7c, 0x48, 0x2f, 0x8f, 0x4b, 0x12, 0x25, 0xc4, 0x8a}}
+	return a, nil
+}
+
+var _insert_migrationSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x49\x4e\x53\x45\x52\x54\x20\x49\x4e\x54\x4f\x20\x6d\x69\x67\x72\x61\x74\x69\x6f\x6e\x73\x20\x28\x69\x64\x29\x20\x56\x41\x4c\x55\x45\x53\x20\x28\x24\x31\x29\x0a\x4f\x4e\x20\x43\x4f\x4e\x46\x4c\x49\x43\x54\x20\x44\x4f\x20\x4e\x4f\x54\x48\x49\x4e\x47\x0a\x03\x00\x2d\xf2\x44\xf0\x3f\x00\x00\x00")
+
+func insert_migrationSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_insert_migrationSql,
+		"insert_migration.sql",
+	)
+}
+
+func insert_migrationSql() (*asset, error) {
+	bytes, err := insert_migrationSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "insert_migration.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa7, 0xb, 0x35, 0x91, 0x57, 0xbc, 0x81, 0xdd, 0x20, 0xc9, 0x93, 0xf6, 0xfc, 0x1e, 0x21, 0x34, 0x86, 0xb, 0x25, 0x9a, 0xc3, 0x6f, 0xa6, 0x1a, 0x89, 0x8f, 0x6, 0x9f, 0x3d, 0x6b, 0x62, 0xb4}}
+	return a, nil
+}
+
+var _label_facesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x63\x00\x9c\xff\x55\x50\x44\x41\x54\x45\x20\x66\x61\x63\x65\x73\x20\x53\x45\x54\x20\x69\x64\x6f\x6c\x5f\x69\x64\x20\x3d\x20\x24\x31\x2c\x20\x69\x64\x6f\x6c\x5f\x63\x6f\x6e\x66\x69\x72\x6d\x65\x64\x20\x3d\x20\x54\x52\x55\x45\x0a\x57\x48\x45\x52\x45\x20\x69\x64\x20\x3d\x20\x41\x4e\x59\x28\x24\x32\x29\x20\x41\x4e\x44\x20\x69\x64\x6f\x6c\x5f\x63\x6f\x6e\x66\x69\x72\x6d\x65\x64\x20\x3d\x20\x46\x41\x4c\x53\x45\x0a\x03\x00\x64\x90\x46\xb7\x63\x00\x00\x00")
+
+func label_facesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_label_facesSql,
+		"label_faces.sql",
+	)
+}
+
+func label_facesSql() (*asset, error) {
+	bytes, err := label_facesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "label_faces.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf0, 0x65, 0xd4, 0x82, 0x5d, 0x6b, 0x82, 0x2f, 0x4d, 0x8c, 0xaa, 0xb8, 0x30, 0xa9, 0xa8, 0xdc, 0xa5, 0xf5, 0x9a, 0xc9, 0xcf, 0xf9, 0x8e, 0x5d, 0xcd, 0xf1, 0x49, 0x68, 0x93, 0xf1, 0x1f, 0xef}}
+	return a, nil
+}
+
+var _migrate_001_txid_revisionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x51\xcb\x4e\xe3\x3c\x18\xdd\xfb\x29\xce\xa2\x12\xad\x44\x79\x00\x2a\x16\xa1\x71\xfb\x47\x0a\x09\x7f\xe2\x4a\xb3\x8b\x4c\xf2\x35\xb1\x48\x6d\xb0\xdd\x86\xbe\xfd\xc8\x69\x61\x0a\x73\x91\x66\x76\xd6\xd1\xb9\xfa\x9b\xcf\x51\xd0\x41\x39\x65\xb4\xc3\xd6\x9a\x1d\x7c\x47\x70\xf4\xba\x27\x5d\x13\x06\xb2\x04\x2f\x9f\x49\xc3\x68\x0c\x56\x79\x82\x95\xbe\x23\x0b\xdf\xc9\x11\xac\xcd\x6e\xa7\xfc\x35\x9b\xcf\xe1\x0c\xea\x5e\x91\xf6\x0e\x3b\xe5\x1c\x35\xa8\x3b\xa9\x5b\x72\x30\x5b\x78\x2b\xb5\x93\xb5\x1f\x93\x86\x4e\xd5\xdd\x59\xea\xa9\x81\xd9\x7b\x98\x6d\xf0\x30\xb6\x21\x7b\x83\x72\x50\xbe\xee\xe0\xcd\xa5\x0e\x49\xec\x6e\xc0\xdf\x94\xf3\x4a\xb7\xb0\x66\x70\x68\xc9\xc3\x9e\x17\xa0\xb5\x24\x3d\xd9\xe0\x33\xd6\x93\xfa\x08\xd3\x37\x30\x9a\x42\x39\x3a\x90\x3d\x9e\x2b\xc2\x1d\x75\xed\xc2\xda\x1d\x64\x2b\x95\xbe\x61\x71\x91\x3f\x42\x14\xc9\x7a\xcd\x0b\x24\x2b\xf0\x6f\x49\x29\x4a\x3c\x49\xdd\xb8\xea\x69\xbf\x7b\xa9\x2c\x1d\x90\x67\x27\x64\xf1\x3b\xbe\x6a\x4c\xff\x99\x3f\x22\x0b\xc6\xa2\x54\xf0\x02\x22\xba\x4f\xf9\xc9\x03\x27\x64\x99\xa7\x9b\x87\x2c\xcc\x40\xc9\x05\x62\xbe\x8a\x36\xa9\x80\x7f\x53\x4d\x55\xef\xad\x25\xed\xa7\xb3\xc5\x27\xf9\x68\xf9\xef\xf2\x86\x7a\x0a\x3f\xea\x18\x30\xce\x58\xe6\x59\x29\x8a\x28\xc9\xc4\xc5\x92\x0f\x56\xf5\xf2\x4c\xc7\x6b\x86\xbf\x0b\x64\x71\x8e\xc9\x84\xc5\x7c\x99\x46\x05\x67\x80\xa6\x61\xfc\x92\x27\xd5\x2a\xed\x71\x7b\xf7\x93\xe4\x9e\xaf\x93\x8c\x21\x74\xf0\xa6\xb2\xd4\xd6\xbd\x74\x6e\x7a\xf5\x62\xcd\x56\xf5\x14\xd4\x95\xa3\xd7\xab\x19\x92\x12\x59\x2e\x90\x6d\xd2\x14\xe2\x3f\x1e\x44\x3f\x02\x6e\xef\xb0\x2e\x78\x24\x78\x29\xa6\x67\xec\x1a\xd3\x92\xa7\x7c\x29\xd0\x4b\xe7\xab\x83\xec\xf7\x84\x55\x91\x3f\xe0\x8b\xf7\x6c\xb6\x60\x00\xcf\x62\x24\xab\xf0\xda\x3c\xc6\x91\x78\xbf\x57\x98\x1b\x02\xee\xde\xa3\x2e\x18\xa7\x93\xfc\x89\xf1\xf1\x9f\xbf\x62\xf1\x2c\x5e\xb0\xc9\x24\x7c\x5b\x38\x48\xc9\xff\xdf\xf0\x6c\xc9\x2f\xce\xf1\xa5\xe8\x82\x7d\x1f\x00\x0f\x03\x4f\xe3\xbd\x03\x00\x00")
+
+func migrate_001_txid_revisionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrate_001_txid_revisionsSql,
+		"migrate_001_txid_revisions.sql",
+	)
+}
+
+func migrate_001_txid_revisionsSql() (*asset, error) {
+	bytes, err := migrate_001_txid_revisionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrate_001_txid_revisions.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe5, 0x4c, 0xa3, 0xd7, 0x9d, 0xa6, 0x1e, 0x98, 0x99, 0x70, 0x4e, 0x62, 0x34, 0x24, 0x72, 0xbb, 0xa1, 0x2, 0x68, 0x67, 0xb0, 0x2c, 0x66, 0x3f, 0x9, 0x46, 0xf5, 0x2, 0x54, 0x35, 0x7d, 0x48}}
+	return a, nil
+}
+
+var _migrate_002_primary_membershipsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcd\xc1\x0a\x82\x40\x14\x85\xe1\xfd\x3c\xc5\x59\x16\xa4\x2f\xd0\xd2\xb4\x06\xf4\x5e\xd0\xd9\x4b\x32\x57\xbb\xa0\x4e\xcd\xb8\xe8\xf1\x23\x5a\xe4\xf6\xf0\x71\xfe\x2c\x43\x23\xcb\x20\x31\x3d\xf4\x99\x10\x46\xa8\x0f\x73\x82\xbc\x35\x6d\xba\x4e\x18\x64\x0c\x51\x30\xae\xfd\xf2\x77\x79\x7a\xcd\xd8\xa2\x4e\x93\xc4\xdc\x58\xea\xca\xd6\xc1\x92\x63\xec\x10\x0e\xdf\xab\x5e\xfd\x09\xc3\x7d\xf5\xbd\xfa\xa3\x01\xba\xb2\x2e\x0b\x87\xdd\x8a\xaa\xe5\xe6\x97\x35\x00\x13\x0a\xa6\xaa\xb6\x85\xc3\x85\x41\xec\x6e\x96\xae\x67\xf3\x19\x00\xdd\xd1\x0e\x9a\xa9\x00\x00\x00")
+
+func migrate_002_primary_membershipsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrate_002_primary_membershipsSql,
+		"migrate_002_primary_memberships.sql",
+	)
+}
+
+func migrate_002_primary_membershipsSql() (*asset, error) {
+	bytes, err := migrate_002_primary_membershipsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrate_002_primary_memberships.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x16, 0x6a, 0xab, 0x79, 0x20, 0x1, 0x38, 0xb5, 0xf9, 0x2e, 0x56, 0x34, 0x8f, 0xd0, 0xac, 0xe0, 0xd0, 0xb6, 0x0, 0x76, 0xac, 0xeb, 0xbe, 0x69, 0x8d, 0xf8, 0x50, 0x95, 0x5d, 0xa0, 0x7f, 0x6}}
+	return a, nil
+}
+
+var _revoke_api_keySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4c\x00\xb3\xff\x55\x50\x44\x41\x54\x45\x20\x61\x70\x69\x5f\x6b\x65\x79\x73\x20\x53\x45\x54\x20\x72\x65\x76\x6f\x6b\x65\x64\x5f\x61\x74\x20\x3d\x20\x6e\x6f\x77\x28\x29\x0a\x57\x48\x45\x52\x45\x20\x69\x64\x20\x3d\x20\x24\x31\x20\x41\x4e\x44\x20\x72\x65\x76\x6f\x6b\x65\x64\x5f\x61\x74\x20\x49\x53\x20\x4e\x55\x4c\x4c\x0a\x03\x00\xd3\x79\x7f\x0f\x4c\x00\x00\x00")
+
+func revoke_api_keySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_revoke_api_keySql,
+		"revoke_api_key.sql",
+	)
+}
+
+func revoke_api_keySql() (*asset, error) {
+	bytes, err := revoke_api_keySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "revoke_api_key.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0x7b, 0x3c, 0xd7, 0x75, 0x30, 0x72, 0xb7, 0x7b, 0x9f, 0x49, 0x7, 0x64, 0x46, 0xbc, 0x37, 0x51, 0xc3, 0x6e, 0x52, 0xfa, 0x8, 0xcf, 0xcb, 0x2, 0x4b, 0xf3, 0xf, 0x85, 0x5e, 0x7e, 0x6c}}
 	return a, nil
 }
 
@@ -264,11 +684,31 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"get_bands.sql":         get_bandsSql,
-	"get_idol_previews.sql": get_idol_previewsSql,
-	"get_idols.sql":         get_idolsSql,
-	"get_train_data.sql":    get_train_dataSql,
-	"init_db.sql":           init_dbSql,
+	"fn_memberships.sql":                  fn_membershipsSql,
+	"fn_profile_rev.sql":                  fn_profile_revSql,
+	"get_api_key.sql":                     get_api_keySql,
+	"get_band.sql":                        get_bandSql,
+	"get_band_idols.sql":                  get_band_idolsSql,
+	"get_bands.sql":                       get_bandsSql,
+	"get_deletions.sql":                   get_deletionsSql,
+	"get_faces.sql":                       get_facesSql,
+	"get_idol.sql":                        get_idolSql,
+	"get_idol_exists.sql":                 get_idol_existsSql,
+	"get_idol_previews.sql":               get_idol_previewsSql,
+	"get_idols.sql":                       get_idolsSql,
+	"get_image_exists.sql":                get_image_existsSql,
+	"get_label_conflicts.sql":             get_label_conflictsSql,
+	"get_memberships.sql":                 get_membershipsSql,
+	"get_revision.sql":                    get_revisionSql,
+	"get_train_data.sql":                  get_train_dataSql,
+	"init_db.sql":                         init_dbSql,
+	"insert_api_key.sql":                  insert_api_keySql,
+	"insert_idol.sql":                     insert_idolSql,
+	"insert_migration.sql":                insert_migrationSql,
+	"label_faces.sql":                     label_facesSql,
+	"migrate_001_txid_revisions.sql":      migrate_001_txid_revisionsSql,
+	"migrate_002_primary_memberships.sql": migrate_002_primary_membershipsSql,
+	"revoke_api_key.sql":                  revoke_api_keySql,
 }
 
 // AssetDir returns the file names below a certain
@@ -312,11 +752,31 @@ type bintree struct {
 }
 
 var _bintree = &bintree{nil, map[string]*bintree{
-	"get_bands.sql":         &bintree{get_bandsSql, map[string]*bintree{}},
-	"get_idol_previews.sql": &bintree{get_idol_previewsSql, map[string]*bintree{}},
-	"get_idols.sql":         &bintree{get_idolsSql, map[string]*bintree{}},
-	"get_train_data.sql":    &bintree{get_train_dataSql, map[string]*bintree{}},
-	"init_db.sql":           &bintree{init_dbSql, map[string]*bintree{}},
+	"fn_memberships.sql":                  &bintree{fn_membershipsSql, map[string]*bintree{}},
+	"fn_profile_rev.sql":                  &bintree{fn_profile_revSql, map[string]*bintree{}},
+	"get_api_key.sql":                     &bintree{get_api_keySql, map[string]*bintree{}},
+	"get_band.sql":                        &bintree{get_bandSql, map[string]*bintree{}},
+	"get_band_idols.sql":                  &bintree{get_band_idolsSql, map[string]*bintree{}},
+	"get_bands.sql":                       &bintree{get_bandsSql, map[string]*bintree{}},
+	"get_deletions.sql":                   &bintree{get_deletionsSql, map[string]*bintree{}},
+	"get_faces.sql":                       &bintree{get_facesSql, map[string]*bintree{}},
+	"get_idol.sql":                        &bintree{get_idolSql, map[string]*bintree{}},
+	"get_idol_exists.sql":                 &bintree{get_idol_existsSql, map[string]*bintree{}},
+	"get_idol_previews.sql":               &bintree{get_idol_previewsSql, map[string]*bintree{}},
+	"get_idols.sql":                       &bintree{get_idolsSql, map[string]*bintree{}},
+	"get_image_exists.sql":                &bintree{get_image_existsSql, map[string]*bintree{}},
+	"get_label_conflicts.sql":             &bintree{get_label_conflictsSql, map[string]*bintree{}},
+	"get_memberships.sql":                 &bintree{get_membershipsSql, map[string]*bintree{}},
+	"get_revision.sql":                    &bintree{get_revisionSql, map[string]*bintree{}},
+	"get_train_data.sql":                  &bintree{get_train_dataSql, map[string]*bintree{}},
+	"init_db.sql":                         &bintree{init_dbSql, map[string]*bintree{}},
+	"insert_api_key.sql":                  &bintree{insert_api_keySql, map[string]*bintree{}},
+	"insert_idol.sql":                     &bintree{insert_idolSql, map[string]*bintree{}},
+	"insert_migration.sql":                &bintree{insert_migrationSql, map[string]*bintree{}},
+	"label_faces.sql":                     &bintree{label_facesSql, map[string]*bintree{}},
+	"migrate_001_txid_revisions.sql":      &bintree{migrate_001_txid_revisionsSql, map[string]*bintree{}},
+	"migrate_002_primary_memberships.sql": &bintree{migrate_002_primary_membershipsSql, map[string]*bintree{}},
+	"revoke_api_key.sql":                  &bintree{revoke_api_keySql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
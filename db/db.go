@@ -3,7 +3,9 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,7 +27,7 @@ func prepare() (err error) {
 	for _, name := range names {
 		id := strings.TrimSuffix(name, ".sql")
 		switch {
-		case strings.HasPrefix(name, "init_"):
+		case strings.HasPrefix(name, "init_"), strings.HasPrefix(name, "migrate_"):
 			// Do nothing.
 		case strings.HasPrefix(name, "fn_"):
 			if err = execQ(id); err != nil {
@@ -54,9 +56,21 @@ func Start(openedDB *sql.DB, connStr string) (err error) {
 		return fmt.Errorf("error initializing database: %v", err)
 	}
 
+	if err = migrate(context.Background()); err != nil {
+		return
+	}
+
 	if err = prepare(); err != nil {
 		return
 	}
 
 	return
 }
+
+// Ping checks that DB is reachable.
+func Ping(ctx context.Context) error {
+	if db == nil {
+		return errors.New("not started")
+	}
+	return db.PingContext(ctx)
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Apply not yet applied migrate_*.sql in order of their names.
+func migrate(ctx context.Context) (err error) {
+	var ids []string
+	for _, name := range AssetNames() {
+		if strings.HasPrefix(name, "migrate_") {
+			ids = append(ids, strings.TrimSuffix(name, ".sql"))
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err = applyMigration(ctx, id); err != nil {
+			return fmt.Errorf("error applying %s: %v", id, err)
+		}
+	}
+	return
+}
+
+// Apply single migration unless it's already recorded. Concurrently
+// starting servers wait on the insert so migration is applied once.
+func applyMigration(ctx context.Context, id string) (err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	res, err := tx.Exec(getQuery("insert_migration"), id)
+	if err != nil {
+		return
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return
+	}
+	_, err = tx.Exec(getQuery(id))
+	return
+}
@@ -1,18 +1,57 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 
 	"github.com/Kagami/go-face"
 	k "github.com/kpopnet/go-kpopnet"
+	"github.com/lib/pq"
 )
 
-// Get all bands.
-func getBands(tx *sql.Tx) (bands []k.Band, bandByID map[string]k.Band, err error) {
-	bands = make([]k.Band, 0)
-	bandByID = make(map[string]k.Band)
-	rs, err := tx.Stmt(prepared["get_bands"]).Query()
+func decodeBand(id string, data []byte) (band *k.Band, err error) {
+	band = &k.Band{}
+	if err = json.Unmarshal(data, band); err != nil {
+		return
+	}
+	band.ID = id
+	err = band.Validate()
+	return
+}
+
+func decodeIdol(id string, bandID string, data []byte) (idol *k.Idol, err error) {
+	idol = &k.Idol{}
+	if err = json.Unmarshal(data, idol); err != nil {
+		return
+	}
+	idol.ID = id
+	idol.BandID = bandID
+	err = idol.Validate()
+	return
+}
+
+// Encode idol info to store in data column.
+func encodeIdol(idol *k.Idol) (data []byte, err error) {
+	if data, err = json.Marshal(idol); err != nil {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+	delete(fields, "id")
+	delete(fields, "band_id")
+	delete(fields, "image_id")
+	return json.Marshal(fields)
+}
+
+// Get all bands changed after the given revision.
+func getBands(tx *sql.Tx, since int64) (bands []*k.Band, bandByID map[string]*k.Band, err error) {
+	bands = make([]*k.Band, 0)
+	bandByID = make(map[string]*k.Band)
+	defer observeQuery("get_bands")()
+	rs, err := tx.Stmt(prepared["get_bands"]).Query(since)
 	if err != nil {
 		return
 	}
@@ -20,14 +59,13 @@ func getBands(tx *sql.Tx) (bands []k.Band, bandByID map[string]k.Band, err error
 	for rs.Next() {
 		var id string
 		var data []byte
-		var band k.Band
+		var band *k.Band
 		if err = rs.Scan(&id, &data); err != nil {
 			return
 		}
-		if err = json.Unmarshal(data, &band); err != nil {
+		if band, err = decodeBand(id, data); err != nil {
 			return
 		}
-		band["id"] = id
 		bands = append(bands, band)
 		bandByID[id] = band
 	}
@@ -37,11 +75,12 @@ func getBands(tx *sql.Tx) (bands []k.Band, bandByID map[string]k.Band, err error
 	return
 }
 
-// Get all idols.
-func getIdols(tx *sql.Tx) (idols []k.Idol, idolByID map[string]k.Idol, err error) {
-	idols = make([]k.Idol, 0)
-	idolByID = make(map[string]k.Idol)
-	rs, err := tx.Stmt(prepared["get_idols"]).Query()
+// Get all idols changed after the given revision.
+func getIdols(tx *sql.Tx, since int64) (idols []*k.Idol, idolByID map[string]*k.Idol, err error) {
+	idols = make([]*k.Idol, 0)
+	idolByID = make(map[string]*k.Idol)
+	defer observeQuery("get_idols")()
+	rs, err := tx.Stmt(prepared["get_idols"]).Query(since)
 	if err != nil {
 		return
 	}
@@ -50,15 +89,13 @@ func getIdols(tx *sql.Tx) (idols []k.Idol, idolByID map[string]k.Idol, err error
 		var id string
 		var bandID string
 		var data []byte
-		var idol k.Idol
+		var idol *k.Idol
 		if err = rs.Scan(&id, &bandID, &data); err != nil {
 			return
 		}
-		if err = json.Unmarshal(data, &idol); err != nil {
+		if idol, err = decodeIdol(id, bandID, data); err != nil {
 			return
 		}
-		idol["id"] = id
-		idol["band_id"] = bandID
 		idols = append(idols, idol)
 		idolByID[id] = idol
 	}
@@ -69,7 +106,8 @@ func getIdols(tx *sql.Tx) (idols []k.Idol, idolByID map[string]k.Idol, err error
 }
 
 // Get and set idol preview property.
-func getIdolPreviews(tx *sql.Tx, idolByID map[string]k.Idol) (err error) {
+func getIdolPreviews(tx *sql.Tx, idolByID map[string]*k.Idol) (err error) {
+	defer observeQuery("get_idol_previews")()
 	rs, err := tx.Stmt(prepared["get_idol_previews"]).Query()
 	if err != nil {
 		return
@@ -82,7 +120,7 @@ func getIdolPreviews(tx *sql.Tx, idolByID map[string]k.Idol) (err error) {
 			return
 		}
 		if idol, ok := idolByID[idolID]; ok {
-			idol["image_id"] = imageID
+			idol.ImageID = imageID
 		}
 	}
 	if err = rs.Err(); err != nil {
@@ -91,18 +129,81 @@ func getIdolPreviews(tx *sql.Tx, idolByID map[string]k.Idol) (err error) {
 	return
 }
 
-// GetProfiles queries all profiles.
-func GetProfiles() (ps *k.Profiles, err error) {
-	tx, err := beginTx()
+// Get all memberships of idols changed after the given revision.
+func getMemberships(tx *sql.Tx, since int64) (ms []*k.Membership, err error) {
+	ms = make([]*k.Membership, 0)
+	defer observeQuery("get_memberships")()
+	rs, err := tx.Stmt(prepared["get_memberships"]).Query(since)
+	if err != nil {
+		return
+	}
+	defer rs.Close()
+	for rs.Next() {
+		m := &k.Membership{}
+		err = rs.Scan(&m.IdolID, &m.BandID, &m.Role, &m.StartDate, &m.EndDate)
+		if err != nil {
+			return
+		}
+		ms = append(ms, m)
+	}
+	if err = rs.Err(); err != nil {
+		return
+	}
+	return
+}
+
+// Get IDs of bands and idols deleted after the given revision.
+func getDeletions(tx *sql.Tx, since int64) (bandIDs []string, idolIDs []string, err error) {
+	bandIDs = make([]string, 0)
+	idolIDs = make([]string, 0)
+	defer observeQuery("get_deletions")()
+	rs, err := tx.Stmt(prepared["get_deletions"]).Query(since)
+	if err != nil {
+		return
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var kind string
+		var id string
+		if err = rs.Scan(&kind, &id); err != nil {
+			return
+		}
+		switch kind {
+		case "band":
+			bandIDs = append(bandIDs, id)
+		case "idol":
+			idolIDs = append(idolIDs, id)
+		}
+	}
+	if err = rs.Err(); err != nil {
+		return
+	}
+	return
+}
+
+// Get profiles changed after the given revision together with deleted
+// ones. Zero revision means all profiles without deletions.
+func getProfiles(tx *sql.Tx, since int64) (ps *k.Profiles, err error) {
+	// Query revision first so changes made concurrently would be
+	// returned again on the next sync rather than lost.
+	var rev int64
+	observe := observeQuery("get_revision")
+	err = tx.Stmt(prepared["get_revision"]).QueryRow().Scan(&rev)
+	observe()
 	if err != nil {
 		return
 	}
-	defer endTx(tx, &err)
-	bands, _, err := getBands(tx)
+	sync := since > 0
+	if since > rev {
+		// Revision from before the switch to transaction IDs, resend
+		// everything.
+		since = 0
+	}
+	bands, _, err := getBands(tx, since)
 	if err != nil {
 		return
 	}
-	idols, idolByID, err := getIdols(tx)
+	idols, idolByID, err := getIdols(tx, since)
 	if err != nil {
 		return
 	}
@@ -110,37 +211,288 @@ func GetProfiles() (ps *k.Profiles, err error) {
 	if err != nil {
 		return
 	}
+	memberships, err := getMemberships(tx, since)
+	if err != nil {
+		return
+	}
 
 	ps = &k.Profiles{
-		Bands: bands,
-		Idols: idols,
+		Revision:    rev,
+		Bands:       bands,
+		Idols:       idols,
+		Memberships: memberships,
+	}
+	if sync {
+		ps.DeletedBands, ps.DeletedIdols, err = getDeletions(tx, since)
+	}
+	return
+}
+
+// GetProfiles queries all profiles.
+func GetProfiles(ctx context.Context) (ps *k.Profiles, err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	ps, err = getProfiles(tx, 0)
+	return
+}
+
+// GetProfilesSince queries profiles changed and deleted after the given
+// revision.
+func GetProfilesSince(ctx context.Context, since int64) (ps *k.Profiles, err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	ps, err = getProfiles(tx, since)
+	return
+}
+
+// GetIdol returns single idol by its ID.
+func GetIdol(ctx context.Context, id string) (idol *k.Idol, err error) {
+	if !isUUID(id) {
+		err = k.ErrUnknownIdol
+		return
+	}
+	var bandID string
+	var data []byte
+	var imageID string
+	defer observeQuery("get_idol")()
+	err = prepared["get_idol"].QueryRowContext(ctx, id).Scan(&bandID, &data, &imageID)
+	if err == sql.ErrNoRows {
+		err = k.ErrUnknownIdol
+		return
+	}
+	if err != nil {
+		return
 	}
+	if idol, err = decodeIdol(id, bandID, data); err != nil {
+		return
+	}
+	idol.ImageID = imageID
+	return
+}
+
+// Get single band.
+func getBand(tx *sql.Tx, id string) (band *k.Band, err error) {
+	if !isUUID(id) {
+		err = k.ErrUnknownBand
+		return
+	}
+	var data []byte
+	defer observeQuery("get_band")()
+	err = tx.Stmt(prepared["get_band"]).QueryRow(id).Scan(&data)
+	if err == sql.ErrNoRows {
+		err = k.ErrUnknownBand
+		return
+	}
+	if err != nil {
+		return
+	}
+	band, err = decodeBand(id, data)
+	return
+}
+
+// GetBand returns single band by its ID.
+func GetBand(ctx context.Context, id string) (band *k.Band, err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	band, err = getBand(tx, id)
+	return
+}
+
+// GetBandIdols returns all idols who are or were members of the band.
+func GetBandIdols(ctx context.Context, id string) (idols []*k.Idol, err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	if _, err = getBand(tx, id); err != nil {
+		return
+	}
+	idols = make([]*k.Idol, 0)
+	defer observeQuery("get_band_idols")()
+	rs, err := tx.Stmt(prepared["get_band_idols"]).Query(id)
+	if err != nil {
+		return
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var idolID string
+		var bandID string
+		var data []byte
+		var imageID string
+		var idol *k.Idol
+		if err = rs.Scan(&idolID, &bandID, &data, &imageID); err != nil {
+			return
+		}
+		if idol, err = decodeIdol(idolID, bandID, data); err != nil {
+			return
+		}
+		idol.ImageID = imageID
+		idols = append(idols, idol)
+	}
+	if err = rs.Err(); err != nil {
+		return
+	}
+	return
+}
+
+// ImageExists checks whether image with the given SHA1 is known.
+func ImageExists(ctx context.Context, sha1 string) (exists bool, err error) {
+	if !isSHA1(sha1) {
+		return
+	}
+	defer observeQuery("get_image_exists")()
+	err = prepared["get_image_exists"].QueryRowContext(ctx, sha1).Scan(&exists)
 	return
 }
 
 // GetMaps returns idols/bands maps accessable by ID.
-func GetMaps() (idolByID map[string]k.Idol, bandByID map[string]k.Band, err error) {
-	tx, err := beginTx()
+func GetMaps(ctx context.Context) (idolByID map[string]*k.Idol, bandByID map[string]*k.Band, err error) {
+	tx, err := beginTx(ctx)
 	if err != nil {
 		return
 	}
-	defer endTx(tx, &err)
-	if _, idolByID, err = getIdols(tx); err != nil {
+	defer endTx(ctx, tx, &err)
+	if _, idolByID, err = getIdols(tx, 0); err != nil {
 		return
 	}
-	if _, bandByID, err = getBands(tx); err != nil {
+	if _, bandByID, err = getBands(tx, 0); err != nil {
 		return
 	}
 	return
 }
 
+// GetFaces returns all confirmed or unconfirmed faces.
+func GetFaces(ctx context.Context, confirmed bool) (faces []*k.Face, err error) {
+	defer observeQuery("get_faces")()
+	rs, err := prepared["get_faces"].QueryContext(ctx, confirmed)
+	if err != nil {
+		return
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var f k.Face
+		var descrBytes []byte
+		if err = rs.Scan(&f.ID, &f.ImageID, &f.IdolID, &descrBytes); err != nil {
+			return
+		}
+		f.Descriptor = bytes2descr(descrBytes)
+		faces = append(faces, &f)
+	}
+	if err = rs.Err(); err != nil {
+		return
+	}
+	return
+}
+
+// Create new idol. Membership of its primary band is added by trigger.
+func createIdol(tx *sql.Tx, idol *k.Idol) (err error) {
+	idol.ID = newUUID()
+	if err = idol.Validate(); err != nil {
+		return
+	}
+	if _, err = getBand(tx, idol.BandID); err != nil {
+		return
+	}
+	data, err := encodeIdol(idol)
+	if err != nil {
+		return
+	}
+	defer observeQuery("insert_idol")()
+	_, err = tx.Stmt(prepared["insert_idol"]).Exec(idol.ID, idol.BandID, data)
+	return
+}
+
+// Get requested faces which would make idol have several faces on the
+// same image.
+func getLabelConflicts(tx *sql.Tx, idolID string, faceIDs []int64) (conflicts []int64, err error) {
+	defer observeQuery("get_label_conflicts")()
+	rs, err := tx.Stmt(prepared["get_label_conflicts"]).Query(idolID, pq.Array(faceIDs))
+	if err != nil {
+		return
+	}
+	defer rs.Close()
+	for rs.Next() {
+		var id int64
+		if err = rs.Scan(&id); err != nil {
+			return
+		}
+		conflicts = append(conflicts, id)
+	}
+	err = rs.Err()
+	return
+}
+
+// LabelFaces confirms unconfirmed faces as the given idol. Idol without
+// ID is created first. Returns number of labelled faces. Nothing is
+// labelled if idol would get several faces on the same image.
+func LabelFaces(ctx context.Context, idol *k.Idol, faceIDs []int64) (n int64, err error) {
+	tx, err := beginTx(ctx)
+	if err != nil {
+		return
+	}
+	defer endTx(ctx, tx, &err)
+	if idol.ID == "" {
+		if err = createIdol(tx, idol); err != nil {
+			return
+		}
+	} else {
+		exists := false
+		if isUUID(idol.ID) {
+			observe := observeQuery("get_idol_exists")
+			err = tx.Stmt(prepared["get_idol_exists"]).QueryRow(idol.ID).Scan(&exists)
+			observe()
+			if err != nil {
+				return
+			}
+		}
+		if !exists {
+			err = k.ErrUnknownIdol
+			return
+		}
+	}
+	conflicts, err := getLabelConflicts(tx, idol.ID, faceIDs)
+	if err != nil {
+		return
+	}
+	if len(conflicts) > 0 {
+		err = k.ErrFaceConflict.WithDetails(map[string]interface{}{
+			"face_ids": conflicts,
+		})
+		return
+	}
+	defer observeQuery("label_faces")()
+	res, err := tx.Stmt(prepared["label_faces"]).Exec(idol.ID, pq.Array(faceIDs))
+	if err != nil {
+		return
+	}
+	if n, err = res.RowsAffected(); err != nil {
+		return
+	}
+	if n == 0 {
+		// Rollback created idol.
+		err = k.ErrUnknownFaces
+	}
+	return
+}
+
 // GetTrainData returns confirmed face descriptors.
-func GetTrainData() (data *k.TrainData, err error) {
+func GetTrainData(ctx context.Context) (data *k.TrainData, err error) {
 	var samples []face.Descriptor
 	var cats []int32
 	labels := make(map[int]string)
 
-	rs, err := prepared["get_train_data"].Query()
+	defer observeQuery("get_train_data")()
+	rs, err := prepared["get_train_data"].QueryContext(ctx)
 	if err != nil {
 		return
 	}
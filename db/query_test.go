@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	k "github.com/kpopnet/go-kpopnet"
+)
+
+const testConn = "user=meguca password=meguca dbname=meguca sslmode=disable"
+
+func insertTestBand(t *testing.T, tx *sql.Tx, id string) {
+	_, err := tx.Exec(`INSERT INTO bands (id, data) VALUES ($1, '{"name": "test"}')`, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func containsBand(bands []*k.Band, id string) bool {
+	for _, band := range bands {
+		if band.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRevisionCommitOrder(t *testing.T) {
+	if err := Start(nil, testConn); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const (
+		idA = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0a01"
+		idB = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0a02"
+	)
+	defer db.Exec("DELETE FROM bands WHERE id IN ($1, $2)", idA, idB)
+	ps, err := GetProfiles(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A writes first but commits after B.
+	txA, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txA.Rollback()
+	insertTestBand(t, txA, idA)
+	txB, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	insertTestBand(t, txB, idB)
+	if err := txB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	ps, err = GetProfilesSince(ctx, ps.Revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsBand(ps.Bands, idB) {
+		t.Errorf("committed band %s not synced", idB)
+	}
+	if err := txA.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	ps, err = GetProfilesSince(ctx, ps.Revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsBand(ps.Bands, idA) {
+		t.Errorf("band %s committed out of order is lost", idA)
+	}
+}
+
+func TestBandIdolsAfterStart(t *testing.T) {
+	if err := Start(nil, testConn); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const (
+		bandID = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0b01"
+		idolID = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0b02"
+	)
+	defer db.Exec("DELETE FROM bands WHERE id = $1", bandID)
+	if _, err := db.Exec(`INSERT INTO bands (id, data) VALUES ($1, '{"name": "test"}')`, bandID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO idols (id, band_id, data) VALUES ($1, $2, '{"name": "test"}')`, idolID, bandID); err != nil {
+		t.Fatal(err)
+	}
+	idols, err := GetBandIdols(ctx, bandID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idols) != 1 || idols[0].ID != idolID {
+		t.Errorf("idol inserted after start is not a member: %v", idols)
+	}
+
+	// Primary band is enough even without membership.
+	if _, err := db.Exec("DELETE FROM memberships WHERE idol_id = $1", idolID); err != nil {
+		t.Fatal(err)
+	}
+	if idols, err = GetBandIdols(ctx, bandID); err != nil {
+		t.Fatal(err)
+	}
+	if len(idols) != 1 || idols[0].ID != idolID {
+		t.Errorf("idol of primary band is not a member: %v", idols)
+	}
+}
+
+func insertTestFace(t *testing.T, imageID, idolID string, confirmed bool) (id int64) {
+	err := db.QueryRow(`
+		INSERT INTO faces (rectangle, descriptor, image_id, idol_id, idol_confirmed, source)
+		VALUES ('((0,0),(1,1))', decode(repeat('00', 512), 'hex'), $1, $2, $3, 'test')
+		RETURNING id`, imageID, idolID, confirmed).Scan(&id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestLabelFacesConflict(t *testing.T) {
+	if err := Start(nil, testConn); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	const (
+		bandID = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0c01"
+		idolA  = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0c02"
+		idolB  = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0c03"
+		idolC  = "5a4ec3f2-1c4e-4f55-9d61-6f8a3f0a0c04"
+		image1 = "c0ffee0000000000000000000000000000000001"
+		image2 = "c0ffee0000000000000000000000000000000002"
+	)
+	defer db.Exec("DELETE FROM bands WHERE id = $1", bandID)
+	if _, err := db.Exec(`INSERT INTO bands (id, data) VALUES ($1, '{"name": "test"}')`, bandID); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{idolA, idolB, idolC} {
+		if _, err := db.Exec(`INSERT INTO idols (id, band_id, data) VALUES ($1, $2, '{"name": "test"}')`, id, bandID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Two faces of the cluster are on the same image.
+	f1 := insertTestFace(t, image1, idolB, false)
+	f2 := insertTestFace(t, image1, idolC, false)
+	_, err := LabelFaces(ctx, &k.Idol{ID: idolA}, []int64{f1, f2})
+	var kerr *k.Error
+	if !errors.As(err, &kerr) || kerr.Code != k.ErrFaceConflict.Code {
+		t.Fatalf("expected face conflict but got %v", err)
+	}
+	if ids := kerr.Details["face_ids"].([]int64); len(ids) != 2 || ids[0] != f1 || ids[1] != f2 {
+		t.Errorf("bad conflicting faces: %v", ids)
+	}
+
+	// Idol already has a face on the image.
+	insertTestFace(t, image2, idolA, true)
+	f3 := insertTestFace(t, image2, idolB, false)
+	_, err = LabelFaces(ctx, &k.Idol{ID: idolA}, []int64{f1, f3})
+	if !errors.As(err, &kerr) || kerr.Code != k.ErrFaceConflict.Code {
+		t.Fatalf("expected face conflict but got %v", err)
+	}
+	if ids := kerr.Details["face_ids"].([]int64); len(ids) != 1 || ids[0] != f3 {
+		t.Errorf("bad conflicting faces: %v", ids)
+	}
+
+	// Nothing was labelled so faces without conflicts can be.
+	if n, err := LabelFaces(ctx, &k.Idol{ID: idolA}, []int64{f1}); err != nil || n != 1 {
+		t.Errorf("error labelling face without conflict: %d %v", n, err)
+	}
+}
@@ -0,0 +1,13 @@
+-- Keep primary band of idol a membership. Membership of the previous
+-- primary band is left as is since idol was its member.
+CREATE OR REPLACE FUNCTION add_primary_membership() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO memberships (idol_id, band_id) VALUES (NEW.id, NEW.band_id)
+  ON CONFLICT DO NOTHING;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS idols_add_primary_membership ON idols;
+CREATE TRIGGER idols_add_primary_membership AFTER INSERT OR UPDATE OF band_id ON idols
+  FOR EACH ROW EXECUTE PROCEDURE add_primary_membership();
@@ -0,0 +1,56 @@
+-- Revision is ID of the writing transaction, see get_revision.sql.
+CREATE OR REPLACE FUNCTION bump_rev() RETURNS trigger AS $$
+BEGIN
+  NEW.rev := txid_current();
+  NEW.updated_at := now();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- First argument is entity kind.
+CREATE OR REPLACE FUNCTION record_deletion() RETURNS trigger AS $$
+BEGIN
+  INSERT INTO deletions (kind, id) VALUES (TG_ARGV[0], OLD.id);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Bump revision of idol on related data change. First argument is the
+-- name of idol ID column.
+CREATE OR REPLACE FUNCTION touch_idol() RETURNS trigger AS $$
+BEGIN
+  IF TG_OP <> 'INSERT' THEN
+    UPDATE idols SET updated_at = now()
+    WHERE id = (to_jsonb(OLD)->>TG_ARGV[0])::uuid;
+  END IF;
+  IF TG_OP <> 'DELETE' THEN
+    UPDATE idols SET updated_at = now()
+    WHERE id = (to_jsonb(NEW)->>TG_ARGV[0])::uuid;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS bands_bump_rev ON bands;
+CREATE TRIGGER bands_bump_rev BEFORE UPDATE ON bands
+  FOR EACH ROW EXECUTE PROCEDURE bump_rev();
+
+DROP TRIGGER IF EXISTS idols_bump_rev ON idols;
+CREATE TRIGGER idols_bump_rev BEFORE UPDATE ON idols
+  FOR EACH ROW EXECUTE PROCEDURE bump_rev();
+
+DROP TRIGGER IF EXISTS bands_record_deletion ON bands;
+CREATE TRIGGER bands_record_deletion AFTER DELETE ON bands
+  FOR EACH ROW EXECUTE PROCEDURE record_deletion('band');
+
+DROP TRIGGER IF EXISTS idols_record_deletion ON idols;
+CREATE TRIGGER idols_record_deletion AFTER DELETE ON idols
+  FOR EACH ROW EXECUTE PROCEDURE record_deletion('idol');
+
+DROP TRIGGER IF EXISTS memberships_touch_idol ON memberships;
+CREATE TRIGGER memberships_touch_idol AFTER INSERT OR UPDATE OR DELETE ON memberships
+  FOR EACH ROW EXECUTE PROCEDURE touch_idol('idol_id');
+
+DROP TRIGGER IF EXISTS idol_previews_touch_idol ON idol_previews;
+CREATE TRIGGER idol_previews_touch_idol AFTER INSERT OR UPDATE OR DELETE ON idol_previews
+  FOR EACH ROW EXECUTE PROCEDURE touch_idol('id');
@@ -0,0 +1,2 @@
+SELECT id, name, scopes, created_at FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL
@@ -0,0 +1 @@
+SELECT data FROM bands WHERE id = $1
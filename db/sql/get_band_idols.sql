@@ -0,0 +1,7 @@
+-- Primary band is checked too in case its membership is missing.
+SELECT i.id, i.band_id, i.data, COALESCE(p.image_id, '')
+FROM idols i
+LEFT JOIN idol_previews p ON p.id = i.id
+WHERE i.band_id = $1
+  OR i.id IN (SELECT idol_id FROM memberships WHERE band_id = $1)
+ORDER BY i.data->>'name'
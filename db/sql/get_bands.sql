@@ -1 +1 @@
-SELECT id, data FROM bands
+SELECT id, data FROM bands WHERE rev > $1
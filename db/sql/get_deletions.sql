@@ -0,0 +1,3 @@
+SELECT kind, id FROM deletions
+WHERE rev > $1
+ORDER BY rev
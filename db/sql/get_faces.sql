@@ -0,0 +1,3 @@
+SELECT id, image_id, idol_id, descriptor FROM faces
+WHERE idol_confirmed = $1
+ORDER BY id
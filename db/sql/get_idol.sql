@@ -0,0 +1,4 @@
+SELECT i.band_id, i.data, COALESCE(p.image_id, '')
+FROM idols i
+LEFT JOIN idol_previews p ON p.id = i.id
+WHERE i.id = $1
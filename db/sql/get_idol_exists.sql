@@ -0,0 +1 @@
+SELECT EXISTS (SELECT 1 FROM idols WHERE id = $1)
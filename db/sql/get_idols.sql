@@ -1 +1 @@
-SELECT id, band_id, data FROM idols
+SELECT id, band_id, data FROM idols WHERE rev > $1
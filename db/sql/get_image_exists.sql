@@ -0,0 +1 @@
+SELECT EXISTS (SELECT 1 FROM images WHERE sha1 = $1)
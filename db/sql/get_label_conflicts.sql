@@ -0,0 +1,10 @@
+-- Requested faces which can't be labelled as the idol because of unique
+-- (image_id, idol_id): idol already has another face on the same image
+-- or another requested face is on the same image.
+SELECT f.id FROM faces f
+WHERE f.id = ANY($2) AND f.idol_confirmed = FALSE
+  AND EXISTS (
+    SELECT 1 FROM faces o
+    WHERE o.image_id = f.image_id AND o.id <> f.id
+      AND (o.idol_id = $1 OR (o.id = ANY($2) AND o.idol_confirmed = FALSE)))
+ORDER BY f.id
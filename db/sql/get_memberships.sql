@@ -0,0 +1,7 @@
+SELECT m.idol_id, m.band_id, COALESCE(m.role, ''),
+       COALESCE(to_char(m.start_date, 'YYYY-MM-DD'), ''),
+       COALESCE(to_char(m.end_date, 'YYYY-MM-DD'), '')
+FROM memberships m
+JOIN idols i ON i.id = m.idol_id
+WHERE i.rev > $1
+ORDER BY m.idol_id, m.start_date NULLS FIRST
@@ -0,0 +1,5 @@
+-- Revisions are IDs of writing transactions which commit in arbitrary
+-- order. All transactions below the snapshot's xmin are finished, so
+-- changes with greater revision not visible yet will be returned on the
+-- next sync.
+SELECT txid_snapshot_xmin(txid_current_snapshot()) - 1
@@ -35,3 +35,59 @@ CREATE TABLE IF NOT EXISTS faces (
 );
 
 CREATE INDEX IF NOT EXISTS faces_idol_id on faces (idol_id);
+
+-- Idol can be a member of several bands (sub-units, project groups,
+-- transfers). idols.band_id is kept as the primary band which is always
+-- a membership, see fn_memberships.sql.
+CREATE TABLE IF NOT EXISTS memberships (
+  idol_id uuid NOT NULL REFERENCES idols ON DELETE CASCADE,
+  band_id uuid NOT NULL REFERENCES bands ON DELETE CASCADE,
+  role varchar(100),
+  start_date date,
+  end_date date,
+  PRIMARY KEY (idol_id, band_id),
+  CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS memberships_band_id on memberships (band_id);
+
+-- Revisions for incremental profile sync. Every band/idol change gets ID
+-- of the writing transaction as revision, deleted entities are recorded to
+-- be able to tell clients about them. See fn_profile_rev.sql for triggers
+-- and get_revision.sql for why transaction IDs.
+ALTER TABLE bands
+  ADD COLUMN IF NOT EXISTS rev bigint NOT NULL DEFAULT txid_current(),
+  ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
+
+ALTER TABLE idols
+  ADD COLUMN IF NOT EXISTS rev bigint NOT NULL DEFAULT txid_current(),
+  ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS bands_rev on bands (rev);
+CREATE INDEX IF NOT EXISTS idols_rev on idols (rev);
+
+CREATE TABLE IF NOT EXISTS deletions (
+  rev bigint NOT NULL DEFAULT txid_current(),
+  kind varchar(10) NOT NULL CHECK (kind IN ('band', 'idol')),
+  id uuid NOT NULL,
+  deleted_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS deletions_rev on deletions (rev);
+
+-- API clients. Only SHA-256 of the key is stored, revoked keys are kept
+-- for reference.
+CREATE TABLE IF NOT EXISTS api_keys (
+  id bigserial PRIMARY KEY,
+  key_hash char(64) UNIQUE NOT NULL,
+  name varchar(100) NOT NULL,
+  scopes varchar(20)[] NOT NULL,
+  created_at timestamptz NOT NULL DEFAULT now(),
+  revoked_at timestamptz
+);
+
+-- Applied one-off migrations, see migrate_*.sql.
+CREATE TABLE IF NOT EXISTS migrations (
+  id varchar(100) PRIMARY KEY,
+  applied_at timestamptz NOT NULL DEFAULT now()
+);
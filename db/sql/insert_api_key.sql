@@ -0,0 +1,2 @@
+INSERT INTO api_keys (key_hash, name, scopes) VALUES ($1, $2, $3)
+RETURNING id, created_at
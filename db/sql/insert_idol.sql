@@ -0,0 +1 @@
+INSERT INTO idols (id, band_id, data) VALUES ($1, $2, $3)
@@ -0,0 +1,2 @@
+INSERT INTO migrations (id) VALUES ($1)
+ON CONFLICT DO NOTHING
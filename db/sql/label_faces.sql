@@ -0,0 +1,2 @@
+UPDATE faces SET idol_id = $1, idol_confirmed = TRUE
+WHERE id = ANY($2) AND idol_confirmed = FALSE
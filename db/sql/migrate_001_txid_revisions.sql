@@ -0,0 +1,27 @@
+-- Revisions from the sequence were taken on write rather than on commit,
+-- so clients missed changes of transactions which committed out of
+-- order. Switch to transaction IDs. Existing rows get revision greater
+-- than any old one so every client syncs them again.
+DROP TRIGGER IF EXISTS bands_bump_rev ON bands;
+DROP TRIGGER IF EXISTS idols_bump_rev ON idols;
+
+ALTER TABLE bands ALTER COLUMN rev SET DEFAULT txid_current();
+ALTER TABLE idols ALTER COLUMN rev SET DEFAULT txid_current();
+ALTER TABLE deletions
+  DROP CONSTRAINT IF EXISTS deletions_pkey,
+  ALTER COLUMN rev SET DEFAULT txid_current();
+
+DO $$
+DECLARE
+  new_rev bigint := txid_current();
+BEGIN
+  IF to_regclass('profile_rev_seq') IS NOT NULL THEN
+    new_rev := GREATEST(new_rev, (SELECT last_value FROM profile_rev_seq));
+  END IF;
+  UPDATE bands SET rev = new_rev;
+  UPDATE idols SET rev = new_rev;
+  UPDATE deletions SET rev = new_rev;
+END;
+$$;
+
+DROP SEQUENCE IF EXISTS profile_rev_seq;
@@ -0,0 +1,4 @@
+-- Memberships of idols existing before fn_memberships.sql trigger.
+INSERT INTO memberships (idol_id, band_id)
+  SELECT id, band_id FROM idols
+  ON CONFLICT DO NOTHING;
@@ -1,42 +1,75 @@
 package db
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	"image"
-	"log"
 	"regexp"
-	"runtime/debug"
 	"strconv"
+	"time"
 	"unsafe"
 
+	"github.com/kpopnet/go-kpopnet/logging"
+	"github.com/kpopnet/go-kpopnet/metrics"
+
 	"github.com/Kagami/go-face"
 )
 
-func logError(err error) {
-	log.Printf("kpopnet: %s\n%s\n", err, debug.Stack())
-}
-
 func execQ(queryID string) (err error) {
 	_, err = db.Exec(getQuery(queryID))
 	return
 }
 
-func beginTx() (tx *sql.Tx, err error) {
-	return db.Begin()
+// Start measuring duration of the query, returned function finishes it.
+func observeQuery(queryID string) func() {
+	start := time.Now()
+	return func() {
+		metrics.DBQueryDuration.WithLabelValues(queryID).Observe(time.Since(start).Seconds())
+	}
+}
+
+func beginTx(ctx context.Context) (tx *sql.Tx, err error) {
+	return db.BeginTx(ctx, nil)
 }
 
-func endTx(tx *sql.Tx, err *error) {
+func endTx(ctx context.Context, tx *sql.Tx, err *error) {
 	if *err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			// Can only log this because original err should be preserved.
-			logError(rbErr)
+			logging.Error(ctx, "error rolling back transaction", "error", rbErr)
 		}
 		return
 	}
 	*err = tx.Commit()
 }
 
+var uuidRe = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Check ID before passing it to PostgreSQL to not get syntax error.
+func isUUID(id string) bool {
+	return uuidRe.MatchString(id)
+}
+
+// Generate random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+var sha1Re = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func isSHA1(id string) bool {
+	return sha1Re.MatchString(id)
+}
+
 // PostgreSQL to Go type mappers.
 
 func rect2str(rect image.Rectangle) string {
@@ -0,0 +1,21 @@
+package db
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet/metrics"
+)
+
+func TestObserveQuery(t *testing.T) {
+	observeQuery("test_query")()
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+	expected := `kpopnet_db_query_duration_seconds_count{query="test_query"} 1`
+	if !strings.Contains(string(body), expected) {
+		t.Errorf("query duration isn't observed:\n%s", body)
+	}
+}
@@ -1,19 +1,103 @@
 package kpopnet
 
-import "errors"
+import (
+	"net/http"
+)
+
+// Error is an API error. It's encoded as JSON object with human-readable
+// message, machine-readable code and optional details.
+type Error struct {
+	Message string                 `json:"error"`
+	Code    string                 `json:"code"`
+	Details map[string]interface{} `json:"details,omitempty"`
+	// Status is an HTTP status code of the error.
+	Status int `json:"-"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether errors have the same code so errors with details
+// match their base errors in errors.Is.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// WithDetails returns copy of the error with provided details.
+func (e *Error) WithDetails(details map[string]interface{}) *Error {
+	e2 := *e
+	e2.Details = details
+	return &e2
+}
+
+func newError(status int, code string, message string) *Error {
+	return &Error{Message: message, Code: code, Status: status}
+}
 
 var (
 	// ErrInternal is returned in case of something went wrong on server side.
-	ErrInternal = errors.New("internal error")
+	ErrInternal = newError(http.StatusInternalServerError, "internal", "internal error")
 	// ErrParseForm is returned on malformed HTTP POST form.
-	ErrParseForm = errors.New("error parsing form")
+	ErrParseForm = newError(http.StatusBadRequest, "parse_form", "error parsing form")
+	// ErrBadQuery is returned on missing or malformed query parameters.
+	ErrBadQuery = newError(http.StatusBadRequest, "bad_query", "invalid query")
+	// ErrParseJSON is returned on malformed JSON request.
+	ErrParseJSON = newError(http.StatusBadRequest, "parse_json", "error parsing JSON")
 	// ErrParseFile is returned on input reading error.
-	ErrParseFile = errors.New("error parsing form file")
+	ErrParseFile = newError(http.StatusBadRequest, "parse_file", "error parsing form file")
+	// ErrTooLarge is returned when input file exceeds size limit.
+	ErrTooLarge = newError(http.StatusRequestEntityTooLarge, "too_large", "file is too large")
+	// ErrTooManyFiles is returned when batch request exceeds files limit.
+	ErrTooManyFiles = newError(http.StatusRequestEntityTooLarge, "too_many_files", "too many files")
+	// ErrFetchImage is returned when image can't be fetched by URL.
+	ErrFetchImage = newError(http.StatusBadGateway, "fetch_image", "error fetching image")
+	// ErrForbiddenURL is returned on attempt to fetch image from not allowed
+	// location.
+	ErrForbiddenURL = newError(http.StatusForbidden, "forbidden_url", "URL is not allowed")
+	// ErrUnknownImage is returned when there is no image with requested ID.
+	ErrUnknownImage = newError(http.StatusNotFound, "unknown_image", "unknown image")
 	// ErrBadImage is returned on malformed/unsupported input image.
-	ErrBadImage = errors.New("invalid image")
+	ErrBadImage = newError(http.StatusBadRequest, "bad_image", "invalid image")
 	// ErrNoSingleFace is returned when input image doesn't contain a single face
-	// (0 or several).
-	ErrNoSingleFace = errors.New("not a single face")
+	// (0 or several). Number of found faces is stored in "faces" detail.
+	ErrNoSingleFace = newError(http.StatusBadRequest, "no_single_face", "not a single face")
 	// ErrNoIdol is returned when face wasn't recognized.
-	ErrNoIdol = errors.New("cannot find idol")
+	ErrNoIdol = newError(http.StatusBadRequest, "no_idol", "cannot find idol")
+	// ErrUnknownIdol is returned when there is no idol with requested ID.
+	ErrUnknownIdol = newError(http.StatusNotFound, "unknown_idol", "unknown idol")
+	// ErrUnknownBand is returned when there is no band with requested ID.
+	ErrUnknownBand = newError(http.StatusNotFound, "unknown_band", "unknown band")
+	// ErrUnknownFaces is returned when none of requested faces can be
+	// labelled.
+	ErrUnknownFaces = newError(http.StatusNotFound, "unknown_faces", "unknown faces")
+	// ErrFaceConflict is returned when faces can't be labelled as the idol
+	// because it would have several faces on the same image. Conflicting
+	// faces are stored in "face_ids" detail.
+	ErrFaceConflict = newError(http.StatusConflict, "face_conflict", "several faces of idol on the same image")
+	// ErrUnknownJob is returned when there is no job with requested ID or
+	// it has already expired.
+	ErrUnknownJob = newError(http.StatusNotFound, "unknown_job", "unknown job")
+	// ErrTooManyJobs is returned when too many jobs are waiting for
+	// completion.
+	ErrTooManyJobs = newError(http.StatusServiceUnavailable, "too_many_jobs", "too many pending jobs")
+	// ErrShuttingDown is returned on requests made during graceful
+	// shutdown.
+	ErrShuttingDown = newError(http.StatusServiceUnavailable, "shutting_down", "server is shutting down")
+	// ErrUnauthorized is returned when API key is required but missing
+	// or when it's unknown or revoked.
+	ErrUnauthorized = newError(http.StatusUnauthorized, "unauthorized", "invalid API key")
+	// ErrForbidden is returned when API key lacks scope needed for the
+	// request. Needed scope is stored in "scope" detail.
+	ErrForbidden = newError(http.StatusForbidden, "forbidden", "insufficient scope")
+	// ErrRateLimited is returned when client exceeds its request rate.
+	// Seconds to wait are stored in "retry_after" detail.
+	ErrRateLimited = newError(http.StatusTooManyRequests, "rate_limited", "too many requests")
+	// ErrTooManyFaces is returned when there are too many faces to check
+	// them over HTTP. Limit is stored in "max_faces" detail.
+	ErrTooManyFaces = newError(http.StatusUnprocessableEntity, "too_many_faces", "too many faces")
+	// ErrNotEnabled is returned when requested feature is disabled in
+	// config.
+	ErrNotEnabled = newError(http.StatusNotImplemented, "not_enabled", "feature is not enabled")
 )
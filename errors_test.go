@@ -0,0 +1,41 @@
+package kpopnet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorJSON(t *testing.T) {
+	err := ErrNoSingleFace.WithDetails(map[string]interface{}{"faces": 2})
+	data, _ := json.Marshal(err)
+	expected := `{"error":"not a single face","code":"no_single_face","details":{"faces":2}}`
+	if string(data) != expected {
+		t.Errorf("expected %s but got %s", expected, data)
+	}
+	if ErrNoSingleFace.Details != nil {
+		t.Error("base error shouldn't be modified")
+	}
+
+	quoted := &Error{Message: `bad "quoted" message`, Code: "test"}
+	data, _ = json.Marshal(quoted)
+	var decoded Error
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Message != quoted.Message {
+		t.Errorf("bad encoding of %q: %s", quoted.Message, data)
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", ErrNoSingleFace.WithDetails(nil))
+	if !errors.Is(err, ErrNoSingleFace) {
+		t.Error("error with details should match its base error")
+	}
+	if errors.Is(err, ErrNoIdol) {
+		t.Error("errors with different codes shouldn't match")
+	}
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Status != 400 {
+		t.Errorf("bad status: %v", apiErr)
+	}
+}
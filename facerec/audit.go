@@ -0,0 +1,195 @@
+package facerec
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/db"
+
+	"github.com/Kagami/go-face"
+)
+
+// AuditOptions are thresholds of the faces audit. Zero values mean
+// defaults.
+type AuditOptions struct {
+	// Number of nearest neighbours to check the label against.
+	Neighbours int
+	// Faces of different idols closer than this are duplicates. Can't be
+	// greater than MatchThreshold.
+	DuplicateDistance float64
+	// Idols with less confirmed faces are reported.
+	MinSamples int
+	// Refuse to check more faces since audit is quadratic, 0 means no
+	// limit.
+	MaxFaces int
+}
+
+const (
+	// MaxAuditNeighbours is a maximum number of nearest neighbours to
+	// check the label against.
+	MaxAuditNeighbours = 50
+
+	defaultAuditNeighbours        = 5
+	defaultAuditDuplicateDistance = 0.3
+	defaultAuditMinSamples        = 3
+)
+
+// MislabelledFace is a face whose nearest neighbours mostly belong to
+// another idol.
+type MislabelledFace struct {
+	FaceID int64  `json:"face_id"`
+	IdolID string `json:"idol_id"`
+	// Idol most of the neighbours belong to.
+	NeighbourIdolID  string  `json:"neighbour_idol_id"`
+	NeighbourFaceIDs []int64 `json:"neighbour_face_ids"`
+}
+
+// DuplicateFaces are near-identical faces labelled as different idols.
+type DuplicateFaces struct {
+	FaceIDs  [2]int64  `json:"face_ids"`
+	IdolIDs  [2]string `json:"idol_ids"`
+	Distance float64   `json:"distance"`
+}
+
+// FewSamplesIdol is an idol with too few confirmed faces.
+type FewSamplesIdol struct {
+	IdolID  string  `json:"idol_id"`
+	FaceIDs []int64 `json:"face_ids"`
+}
+
+// AuditReport lists suspicious confirmed faces.
+type AuditReport struct {
+	Faces       int               `json:"faces"`
+	Mislabelled []MislabelledFace `json:"mislabelled"`
+	Duplicates  []DuplicateFaces  `json:"duplicates"`
+	FewSamples  []FewSamplesIdol  `json:"few_samples"`
+}
+
+// AuditFaces checks confirmed faces for mislabelled ones, duplicates and
+// idols without enough samples.
+func AuditFaces(ctx context.Context, opts AuditOptions) (report *AuditReport, err error) {
+	if opts.DuplicateDistance > MatchThreshold {
+		err = kpopnet.ErrBadQuery
+		return
+	}
+	faces, err := db.GetFaces(ctx, true)
+	if err != nil {
+		return
+	}
+	if err = checkMaxFaces(faces, opts.MaxFaces); err != nil {
+		return
+	}
+	report = auditFaces(faces, opts)
+	return
+}
+
+func checkMaxFaces(faces []*kpopnet.Face, max int) error {
+	if max > 0 && len(faces) > max {
+		return kpopnet.ErrTooManyFaces.WithDetails(map[string]interface{}{
+			"max_faces": max,
+		})
+	}
+	return nil
+}
+
+type neighbour struct {
+	idx  int
+	dist float64
+}
+
+// Insert neighbour into the list sorted by distance, keeping at most k
+// closest ones.
+func addNeighbour(ns []neighbour, n neighbour, k int) []neighbour {
+	if len(ns) == k && n.dist >= ns[k-1].dist {
+		return ns
+	}
+	i := sort.Search(len(ns), func(i int) bool { return ns[i].dist > n.dist })
+	if len(ns) < k {
+		ns = append(ns, neighbour{})
+	}
+	copy(ns[i+1:], ns[i:])
+	ns[i] = n
+	return ns
+}
+
+// Fill zero options with defaults and clamp number of neighbours.
+func (opts AuditOptions) withDefaults() AuditOptions {
+	if opts.Neighbours <= 0 {
+		opts.Neighbours = defaultAuditNeighbours
+	} else if opts.Neighbours > MaxAuditNeighbours {
+		opts.Neighbours = MaxAuditNeighbours
+	}
+	if opts.DuplicateDistance <= 0 {
+		opts.DuplicateDistance = defaultAuditDuplicateDistance
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = defaultAuditMinSamples
+	}
+	return opts
+}
+
+func auditFaces(faces []*kpopnet.Face, opts AuditOptions) *AuditReport {
+	opts = opts.withDefaults()
+	report := &AuditReport{
+		Faces:       len(faces),
+		Mislabelled: make([]MislabelledFace, 0),
+		Duplicates:  make([]DuplicateFaces, 0),
+		FewSamples:  make([]FewSamplesIdol, 0),
+	}
+
+	// Brute force is fine for the offline check.
+	neighbours := make([][]neighbour, len(faces))
+	for i, f := range faces {
+		for j := i + 1; j < len(faces); j++ {
+			f2 := faces[j]
+			dist := math.Sqrt(face.SquaredEuclideanDistance(f.Descriptor, f2.Descriptor))
+			neighbours[i] = addNeighbour(neighbours[i], neighbour{j, dist}, opts.Neighbours)
+			neighbours[j] = addNeighbour(neighbours[j], neighbour{i, dist}, opts.Neighbours)
+			if f.IdolID != f2.IdolID && dist < opts.DuplicateDistance {
+				report.Duplicates = append(report.Duplicates, DuplicateFaces{
+					FaceIDs:  [2]int64{f.ID, f2.ID},
+					IdolIDs:  [2]string{f.IdolID, f2.IdolID},
+					Distance: dist,
+				})
+			}
+		}
+	}
+
+	for i, f := range faces {
+		counts := make(map[string]int)
+		var topIdolID string
+		for _, n := range neighbours[i] {
+			idolID := faces[n.idx].IdolID
+			counts[idolID]++
+			if counts[idolID] > counts[topIdolID] {
+				topIdolID = idolID
+			}
+		}
+		if topIdolID == "" || topIdolID == f.IdolID ||
+			counts[topIdolID]*2 <= len(neighbours[i]) {
+			continue
+		}
+		mf := MislabelledFace{FaceID: f.ID, IdolID: f.IdolID, NeighbourIdolID: topIdolID}
+		for _, n := range neighbours[i] {
+			mf.NeighbourFaceIDs = append(mf.NeighbourFaceIDs, faces[n.idx].ID)
+		}
+		report.Mislabelled = append(report.Mislabelled, mf)
+	}
+
+	faceIDs := make(map[string][]int64)
+	var idolIDs []string
+	for _, f := range faces {
+		if _, ok := faceIDs[f.IdolID]; !ok {
+			idolIDs = append(idolIDs, f.IdolID)
+		}
+		faceIDs[f.IdolID] = append(faceIDs[f.IdolID], f.ID)
+	}
+	for _, idolID := range idolIDs {
+		if ids := faceIDs[idolID]; len(ids) < opts.MinSamples {
+			report.FewSamples = append(report.FewSamples, FewSamplesIdol{idolID, ids})
+		}
+	}
+	return report
+}
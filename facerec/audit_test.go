@@ -0,0 +1,95 @@
+package facerec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func testFace(id int64, idolID string, x float32) *kpopnet.Face {
+	f := &kpopnet.Face{ID: id, IdolID: idolID}
+	f.Descriptor[0] = x
+	return f
+}
+
+func TestAuditFaces(t *testing.T) {
+	faces := []*kpopnet.Face{
+		testFace(1, "a", 0),
+		testFace(2, "a", 0.1),
+		testFace(3, "a", 0.2),
+		testFace(4, "b", 5),
+		testFace(5, "b", 5.1),
+		testFace(6, "b", 5.2),
+		// Looks like b.
+		testFace(7, "a", 5.15),
+		// Almost the same as face 1.
+		testFace(8, "c", 0.05),
+	}
+	report := auditFaces(faces, AuditOptions{Neighbours: 3})
+	if report.Faces != 8 {
+		t.Errorf("bad faces count: %d", report.Faces)
+	}
+
+	if len(report.Mislabelled) != 2 {
+		t.Fatalf("expected two mislabelled faces: %+v", report.Mislabelled)
+	}
+	mf := report.Mislabelled[0]
+	if mf.FaceID != 7 || mf.NeighbourIdolID != "b" || len(mf.NeighbourFaceIDs) != 3 {
+		t.Errorf("bad mislabelled face: %+v", mf)
+	}
+	if mf = report.Mislabelled[1]; mf.FaceID != 8 || mf.NeighbourIdolID != "a" {
+		t.Errorf("bad mislabelled face: %+v", mf)
+	}
+
+	dups := make(map[[2]int64]bool)
+	for _, d := range report.Duplicates {
+		dups[d.FaceIDs] = true
+	}
+	if !dups[[2]int64{1, 8}] || !dups[[2]int64{2, 8}] || !dups[[2]int64{6, 7}] {
+		t.Errorf("bad duplicates: %+v", report.Duplicates)
+	}
+
+	if len(report.FewSamples) != 1 || report.FewSamples[0].IdolID != "c" ||
+		report.FewSamples[0].FaceIDs[0] != 8 {
+		t.Errorf("bad few samples: %+v", report.FewSamples)
+	}
+}
+
+func TestAddNeighbour(t *testing.T) {
+	var ns []neighbour
+	for i, dist := range []float64{3, 1, 4, 2, 0.5} {
+		ns = addNeighbour(ns, neighbour{i, dist}, 3)
+	}
+	if len(ns) != 3 || ns[0].idx != 4 || ns[1].idx != 1 || ns[2].idx != 3 {
+		t.Errorf("bad neighbours: %v", ns)
+	}
+}
+
+func TestAuditOptions(t *testing.T) {
+	opts := AuditOptions{Neighbours: 1e9}.withDefaults()
+	if opts.Neighbours != MaxAuditNeighbours {
+		t.Errorf("neighbours aren't clamped: %d", opts.Neighbours)
+	}
+	if opts = (AuditOptions{}).withDefaults(); opts.Neighbours != defaultAuditNeighbours {
+		t.Errorf("expected default neighbours but got %d", opts.Neighbours)
+	}
+	_, err := AuditFaces(context.Background(), AuditOptions{DuplicateDistance: MatchThreshold + 0.1})
+	if !errors.Is(err, kpopnet.ErrBadQuery) {
+		t.Errorf("expected bad query but got %v", err)
+	}
+}
+
+func TestCheckMaxFaces(t *testing.T) {
+	faces := []*kpopnet.Face{testFace(1, "a", 0), testFace(2, "a", 1)}
+	if err := checkMaxFaces(faces, 0); err != nil {
+		t.Errorf("unlimited check failed: %v", err)
+	}
+	if err := checkMaxFaces(faces, 2); err != nil {
+		t.Errorf("check within limit failed: %v", err)
+	}
+	if err := checkMaxFaces(faces, 1); !errors.Is(err, kpopnet.ErrTooManyFaces) {
+		t.Errorf("expected too many faces but got %v", err)
+	}
+}
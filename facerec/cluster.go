@@ -0,0 +1,123 @@
+package facerec
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/db"
+
+	"github.com/Kagami/go-face"
+)
+
+// ClusterOptions are parameters of faces clustering. Zero values mean
+// defaults.
+type ClusterOptions struct {
+	// Maximum distance between neighbour faces of the cluster.
+	Distance float64
+	// Minimum number of faces in the cluster.
+	MinSize int
+	// Refuse to cluster more faces since clustering is quadratic, 0 means
+	// no limit.
+	MaxFaces int
+}
+
+const (
+	defaultClusterDistance = 0.5
+	defaultClusterMinSize  = 3
+)
+
+// Cluster is a group of faces which likely belong to the same person.
+type Cluster struct {
+	FaceIDs  []int64  `json:"face_ids"`
+	ImageIDs []string `json:"image_ids"`
+	// Number of faces per idol they are currently labelled with.
+	Labels map[string]int `json:"labels"`
+}
+
+// ClusterReport lists clusters of unconfirmed faces, largest first.
+type ClusterReport struct {
+	Faces    int        `json:"faces"`
+	Clusters []*Cluster `json:"clusters"`
+	// Number of faces not belonging to any cluster.
+	Noise int `json:"noise"`
+}
+
+// ClusterFaces groups unconfirmed faces into candidate identities.
+func ClusterFaces(ctx context.Context, opts ClusterOptions) (report *ClusterReport, err error) {
+	faces, err := db.GetFaces(ctx, false)
+	if err != nil {
+		return
+	}
+	if err = checkMaxFaces(faces, opts.MaxFaces); err != nil {
+		return
+	}
+	report = clusterFaces(faces, opts)
+	return
+}
+
+// DBSCAN over face descriptors. Brute force is fine for the offline
+// check.
+func clusterFaces(faces []*kpopnet.Face, opts ClusterOptions) *ClusterReport {
+	if opts.Distance <= 0 {
+		opts.Distance = defaultClusterDistance
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = defaultClusterMinSize
+	}
+
+	neighbours := make([][]int, len(faces))
+	for i, f := range faces {
+		for j := i + 1; j < len(faces); j++ {
+			dist := math.Sqrt(face.SquaredEuclideanDistance(f.Descriptor, faces[j].Descriptor))
+			if dist <= opts.Distance {
+				neighbours[i] = append(neighbours[i], j)
+				neighbours[j] = append(neighbours[j], i)
+			}
+		}
+	}
+	// Face itself is counted too.
+	isCore := func(i int) bool { return len(neighbours[i])+1 >= opts.MinSize }
+
+	report := &ClusterReport{
+		Faces:    len(faces),
+		Clusters: make([]*Cluster, 0),
+	}
+	assigned := make([]bool, len(faces))
+	for i := range faces {
+		if assigned[i] || !isCore(i) {
+			continue
+		}
+		c := &Cluster{Labels: make(map[string]int)}
+		queue := []int{i}
+		assigned[i] = true
+		for len(queue) > 0 {
+			j := queue[0]
+			queue = queue[1:]
+			f := faces[j]
+			c.FaceIDs = append(c.FaceIDs, f.ID)
+			c.ImageIDs = append(c.ImageIDs, f.ImageID)
+			c.Labels[f.IdolID]++
+			if !isCore(j) {
+				continue
+			}
+			for _, k := range neighbours[j] {
+				if !assigned[k] {
+					assigned[k] = true
+					queue = append(queue, k)
+				}
+			}
+		}
+		report.Clusters = append(report.Clusters, c)
+	}
+	for _, a := range assigned {
+		if !a {
+			report.Noise++
+		}
+	}
+	sort.SliceStable(report.Clusters, func(i, j int) bool {
+		return len(report.Clusters[i].FaceIDs) > len(report.Clusters[j].FaceIDs)
+	})
+	return report
+}
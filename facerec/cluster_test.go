@@ -0,0 +1,32 @@
+package facerec
+
+import (
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func TestClusterFaces(t *testing.T) {
+	faces := []*kpopnet.Face{
+		testFace(1, "a", 0),
+		testFace(2, "a", 0.3),
+		testFace(3, "b", 0.6),
+		testFace(4, "b", 5),
+		testFace(5, "b", 5.2),
+		testFace(6, "c", 5.4),
+		testFace(7, "c", 5.6),
+		// Far from anything.
+		testFace(8, "c", 20),
+	}
+	report := clusterFaces(faces, ClusterOptions{Distance: 0.35})
+	if report.Faces != 8 || report.Noise != 1 || len(report.Clusters) != 2 {
+		t.Fatalf("bad report: %+v", report)
+	}
+	c := report.Clusters[0]
+	if len(c.FaceIDs) != 4 || c.Labels["b"] != 2 || c.Labels["c"] != 2 {
+		t.Errorf("bad first cluster: %+v", c)
+	}
+	if c = report.Clusters[1]; len(c.FaceIDs) != 3 || c.Labels["a"] != 2 {
+		t.Errorf("bad second cluster: %+v", c)
+	}
+}
@@ -2,16 +2,22 @@ package facerec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"image"
 	"image/color"
 	_ "image/jpeg" // JPEG decoder
 	"io/ioutil"
+	"math"
 	"mime/multipart"
+	"sync"
+	"time"
 
 	"github.com/kpopnet/go-kpopnet"
 	"github.com/kpopnet/go-kpopnet/cache"
 	"github.com/kpopnet/go-kpopnet/db"
+	"github.com/kpopnet/go-kpopnet/logging"
+	"github.com/kpopnet/go-kpopnet/metrics"
 
 	"github.com/Kagami/go-face"
 )
@@ -27,52 +33,205 @@ const (
 var (
 	faceRec *face.Recognizer
 	recJobs = make(chan recRequest)
+
+	// Samples of the classifier and labels of their categories must be
+	// swapped together, otherwise category might be looked up in the
+	// wrong train data.
+	samplesMu      sync.Mutex
+	classifier     sampleClassifier
+	classifierData *trainData
 )
 
+// Part of face.Recognizer used for classification, replaced in tests.
+type sampleClassifier interface {
+	SetSamples(samples []face.Descriptor, cats []int32)
+	Classify(descr face.Descriptor) int
+}
+
 type recRequest struct {
-	fh *multipart.FileHeader
-	ch chan<- recResult
+	ctx     context.Context
+	imgData []byte
+	// Only detect the face, without classification.
+	detectOnly bool
+	ch         chan<- recResult
 }
 
 type recResult struct {
-	idolID *string
-	err    error
+	res   *Result
+	descr face.Descriptor
+	err   error
+}
+
+// Point is a point on the image.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Rect is a rectangle on the image.
+type Rect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Size is a size of the image.
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Result describes recognized face.
+type Result struct {
+	// ID of the recognized idol.
+	IdolID string `json:"id"`
+	// Distance between the face and the closest sample of the idol.
+	Distance float64 `json:"distance"`
+	// Face location.
+	Rectangle Rect `json:"rectangle"`
+	// Face landmarks as returned by the shape predictor: corners of the
+	// eyes and bottom of the nose.
+	Landmarks []Point `json:"landmarks"`
+	// Size of the source image.
+	Image Size `json:"image"`
 }
 
+// Options of face recognition.
+type Options struct {
+	// Models directory location.
+	ModelDir string
+	// Load train data on start instead of the first recognition.
+	WarmTrainData bool
+}
+
+// Status describes readiness of face recognition.
+type Status struct {
+	ModelsLoaded    bool `json:"models_loaded"`
+	TrainDataLoaded bool `json:"train_data_loaded"`
+	// Train data is loaded on start rather than on the first recognition.
+	WarmTrainData bool `json:"warm_train_data"`
+	Samples       int  `json:"samples"`
+	Idols         int  `json:"idols"`
+}
+
+var (
+	statusMu sync.Mutex
+	status   Status
+
+	// Requests which are queued or being recognized.
+	activeMu sync.Mutex
+	active   sync.WaitGroup
+	stopping bool
+)
+
 // Start initializes face recognition.
-func Start(modelDir string) (err error) {
-	faceRec, err = face.NewRecognizer(modelDir)
+func Start(opts Options) (err error) {
+	faceRec, err = face.NewRecognizer(opts.ModelDir)
 	if err != nil {
 		return fmt.Errorf("error initializing face recognizer: %v", err)
 	}
+	classifier = faceRec
+	statusMu.Lock()
+	status.ModelsLoaded = true
+	status.WarmTrainData = opts.WarmTrainData
+	statusMu.Unlock()
+	if opts.WarmTrainData {
+		if _, err = getTrainData(context.Background()); err != nil {
+			return fmt.Errorf("error loading train data: %v", err)
+		}
+	}
 	for i := 0; i < numRecWorkers; i++ {
 		go recWorker()
 	}
 	return
 }
 
+// Stop stops accepting new recognition requests and waits for queued
+// ones to finish.
+func Stop(ctx context.Context) error {
+	activeMu.Lock()
+	stopping = true
+	activeMu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReloadTrainData loads fresh train data from DB. Recognitions in
+// flight keep using the old samples together with their labels.
+func ReloadTrainData(ctx context.Context) (err error) {
+	cache.ClearTrainDataCache()
+	_, err = getTrainData(ctx)
+	return
+}
+
+// GetStatus returns current status of face recognition. Train data
+// stays loaded after cache invalidation until it's reloaded.
+func GetStatus() Status {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	return status
+}
+
 // Execute recognizing jobs.
 func recWorker() {
 	for {
 		req := <-recJobs
-		idolID, err := recognizeMultipart(req.fh)
-		req.ch <- recResult{idolID, err}
+		metrics.RecognitionQueue.Dec()
+		start := time.Now()
+		var r recResult
+		typ := "recognize"
+		if req.detectOnly {
+			typ = "detect"
+			r.res, r.descr, r.err = detect(req.imgData)
+		} else {
+			r.res, r.err = recognize(req.ctx, req.imgData)
+		}
+		took := time.Since(start)
+		outcome := metrics.Outcome(r.err)
+		metrics.Recognitions.WithLabelValues(typ, outcome).Observe(took.Seconds())
+		logging.Debug(req.ctx, "recognition finished",
+			"type", typ, "outcome", outcome, "took_ms", took.Seconds()*1000)
+		req.ch <- r
 	}
 }
 
-// RequestRecognizeMultipart recognizes provided image.
-func RequestRecognizeMultipart(fh *multipart.FileHeader) (idolID *string, err error) {
+// Send request to the workers and wait for result.
+func request(ctx context.Context, imgData []byte, detectOnly bool) recResult {
+	activeMu.Lock()
+	if stopping {
+		activeMu.Unlock()
+		return recResult{err: kpopnet.ErrShuttingDown}
+	}
+	active.Add(1)
+	activeMu.Unlock()
+	defer active.Done()
+
 	ch := make(chan recResult)
+	metrics.RecognitionQueue.Inc()
 	go func() {
-		recJobs <- recRequest{fh, ch}
+		recJobs <- recRequest{ctx, imgData, detectOnly, ch}
 	}()
-	res := <-ch
-	return res.idolID, res.err
+	return <-ch
 }
 
-// Simple wrapper to work with uploaded files.
-// Recognize immediately.
-func recognizeMultipart(fh *multipart.FileHeader) (idolID *string, err error) {
+// RequestRecognize recognizes provided image.
+func RequestRecognize(ctx context.Context, imgData []byte) (res *Result, err error) {
+	r := request(ctx, imgData, false)
+	return r.res, r.err
+}
+
+// RequestRecognizeMultipart recognizes provided uploaded image.
+func RequestRecognizeMultipart(ctx context.Context, fh *multipart.FileHeader) (res *Result, err error) {
 	fd, err := fh.Open()
 	if err != nil {
 		err = kpopnet.ErrParseFile
@@ -84,26 +243,121 @@ func recognizeMultipart(fh *multipart.FileHeader) (idolID *string, err error) {
 		err = kpopnet.ErrParseFile
 		return
 	}
-	idolID, err = recognize(imgData)
-	return
+	return RequestRecognize(ctx, imgData)
 }
 
-// Recognize immediately.
-// TODO(Kagami): Search for already recognized idol using imageId.
-func recognize(imgData []byte) (idolID *string, err error) {
-	// TODO(Kagami): Invalidate?
+// Train data with values derived from it.
+type trainData struct {
+	*kpopnet.TrainData
+	// Category of every idol.
+	cats map[string]int32
+	// Mean descriptor of every category.
+	centroids []face.Descriptor
+	// Closest idols of every category, up to MaxSimilarLimit.
+	neighbours [][]Similar
+}
+
+func newTrainData(data *kpopnet.TrainData) *trainData {
+	td := &trainData{
+		TrainData: data,
+		cats:      make(map[string]int32, len(data.Labels)),
+		centroids: make([]face.Descriptor, len(data.Labels)),
+	}
+	for catID, idolID := range data.Labels {
+		td.cats[idolID] = int32(catID)
+	}
+	counts := make([]int, len(data.Labels))
+	for i, cat := range data.Cats {
+		for j, x := range data.Samples[i] {
+			td.centroids[cat][j] += x
+		}
+		counts[cat]++
+	}
+	for cat, n := range counts {
+		for j := range td.centroids[cat] {
+			td.centroids[cat][j] /= float32(n)
+		}
+	}
+	td.neighbours = make([][]Similar, len(td.centroids))
+	for cat := range td.centroids {
+		td.neighbours[cat] = td.computeSimilar(int32(cat))
+	}
+	return td
+}
+
+// Get train data, cached. Recognizer samples, centroids and similar
+// idols are updated on load.
+// TODO(Kagami): Invalidate?
+func getTrainData(ctx context.Context) (data *trainData, err error) {
 	v, err := cache.Cached(cache.TrainDataCacheKey, func() (interface{}, error) {
-		data, err := db.GetTrainData()
-		if err == nil {
-			faceRec.SetSamples(data.Samples, data.Cats)
+		data, err := db.GetTrainData(ctx)
+		if err != nil {
+			return nil, err
 		}
-		return data, err
+		td := newTrainData(data)
+		setSamples(td)
+		metrics.TrainSamples.Set(float64(len(data.Samples)))
+		metrics.TrainIdols.Set(float64(len(data.Labels)))
+		statusMu.Lock()
+		status.TrainDataLoaded = true
+		status.Samples = len(data.Samples)
+		status.Idols = len(data.Labels)
+		statusMu.Unlock()
+		logging.Info(ctx, "train data loaded",
+			"samples", len(data.Samples), "idols", len(data.Labels))
+		return td, nil
 	})
 	if err != nil {
 		return
 	}
-	data := v.(*kpopnet.TrainData)
+	data = v.(*trainData)
+	return
+}
+
+// Load samples of the train data into classifier.
+func setSamples(data *trainData) {
+	samplesMu.Lock()
+	defer samplesMu.Unlock()
+	classifier.SetSamples(data.Samples, data.Cats)
+	classifierData = data
+}
+
+// Find idol by face descriptor using samples currently loaded into
+// classifier.
+func classify(descr face.Descriptor) (idolID string, distance float64, err error) {
+	samplesMu.Lock()
+	catID := classifier.Classify(descr)
+	data := classifierData
+	samplesMu.Unlock()
+	if catID < 0 {
+		err = kpopnet.ErrNoIdol
+		return
+	}
+	idolID = data.Labels[catID]
+	distance = minDistance(data.TrainData, descr, int32(catID))
+	return
+}
 
+// Recognize immediately.
+// TODO(Kagami): Search for already recognized idol using imageId.
+func recognize(ctx context.Context, imgData []byte) (res *Result, err error) {
+	// Make sure samples are loaded.
+	if _, err = getTrainData(ctx); err != nil {
+		return
+	}
+	res, descr, err := detect(imgData)
+	if err != nil {
+		return
+	}
+	if res.IdolID, res.Distance, err = classify(descr); err != nil {
+		res = nil
+	}
+	return
+}
+
+// Find single face on the image and compute its descriptor. Idol fields
+// of the result are left empty.
+func detect(imgData []byte) (res *Result, descr face.Descriptor, err error) {
 	r := bytes.NewReader(imgData)
 	c, typ, err := image.DecodeConfig(r)
 	if err != nil || typ != "jpeg" ||
@@ -116,19 +370,49 @@ func recognize(imgData []byte) (idolID *string, err error) {
 		return
 	}
 
-	f, err := faceRec.RecognizeSingle(imgData)
+	faces, err := faceRec.Recognize(imgData)
 	if _, ok := err.(face.ImageLoadError); ok {
 		err = kpopnet.ErrBadImage
 	}
-	if err != nil || f == nil {
+	if err != nil {
 		return
 	}
-
-	catID := faceRec.Classify(f.Descriptor)
-	if catID < 0 {
-		err = kpopnet.ErrNoIdol
+	if len(faces) != 1 {
+		err = kpopnet.ErrNoSingleFace.WithDetails(map[string]interface{}{
+			"faces": len(faces),
+		})
 		return
 	}
-	id := data.Labels[catID]
-	return &id, nil
+	f := faces[0]
+
+	rect := f.Rectangle
+	res = &Result{
+		Rectangle: Rect{
+			X:      rect.Min.X,
+			Y:      rect.Min.Y,
+			Width:  rect.Dx(),
+			Height: rect.Dy(),
+		},
+		Landmarks: make([]Point, 0, len(f.Shapes)),
+		Image:     Size{c.Width, c.Height},
+	}
+	for _, p := range f.Shapes {
+		res.Landmarks = append(res.Landmarks, Point{p.X, p.Y})
+	}
+	descr = f.Descriptor
+	return
+}
+
+// Distance to the closest sample of the given category.
+func minDistance(data *kpopnet.TrainData, d face.Descriptor, catID int32) float64 {
+	min := math.Inf(1)
+	for i, cat := range data.Cats {
+		if cat != catID {
+			continue
+		}
+		if dist := face.SquaredEuclideanDistance(data.Samples[i], d); dist < min {
+			min = dist
+		}
+	}
+	return math.Sqrt(min)
 }
@@ -1,6 +1,8 @@
 package facerec
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -8,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/kpopnet/go-kpopnet"
 	"github.com/kpopnet/go-kpopnet/db"
 )
 
@@ -58,7 +61,7 @@ func getTestFilePath(fname string) string {
 	return filepath.Join(testDir, "images", fname)
 }
 
-func recognizeFile(fpath string) (idolID *string, err error) {
+func recognizeFile(fpath string) (res *Result, err error) {
 	fd, err := os.Open(fpath)
 	if err != nil {
 		return
@@ -67,17 +70,17 @@ func recognizeFile(fpath string) (idolID *string, err error) {
 	if err != nil {
 		return
 	}
-	return recognize(imgData)
+	return recognize(context.Background(), imgData)
 }
 
 func TestIdols(t *testing.T) {
 	if err := db.Start(nil, testConn); err != nil {
 		t.Fatal(err)
 	}
-	if err := Start(filepath.Join(testDir, "models")); err != nil {
+	if err := Start(Options{ModelDir: filepath.Join(testDir, "models")}); err != nil {
 		t.Fatal(err)
 	}
-	idolByID, bandByID, err := db.GetMaps()
+	idolByID, bandByID, err := db.GetMaps(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -87,19 +90,19 @@ func TestIdols(t *testing.T) {
 			expectedIname := names[0]
 			expectedBname := names[1]
 
-			actualIdolID, err := recognizeFile(getTestFilePath(fname))
-			if err != nil {
-				t.Fatal(err)
-			}
-			if actualIdolID == nil {
+			res, err := recognizeFile(getTestFilePath(fname))
+			if errors.Is(err, kpopnet.ErrNoSingleFace) {
 				t.Errorf("%s: expected “%s” but not recognized", fname, expected)
 				return
 			}
+			if err != nil {
+				t.Fatal(err)
+			}
 
-			idol := idolByID[*actualIdolID]
-			band := bandByID[idol["band_id"].(string)]
-			actualIname := idol["name"]
-			actualBname := band["name"]
+			idol := idolByID[res.IdolID]
+			band := bandByID[idol.BandID]
+			actualIname := idol.Name
+			actualBname := band.Name
 			if expectedIname != actualIname || expectedBname != actualBname {
 				actual := fmt.Sprintf("%s, %s", actualIname, actualBname)
 				t.Errorf("%s: expected “%s” but got “%s”", fname, expected, actual)
@@ -0,0 +1,75 @@
+package facerec
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/Kagami/go-face"
+)
+
+const (
+	// DefaultSimilarLimit is a number of similar idols returned if limit
+	// isn't set.
+	DefaultSimilarLimit = 10
+	// MaxSimilarLimit is a maximum number of similar idols per query.
+	MaxSimilarLimit = 100
+)
+
+// Similar describes idol which looks like another one.
+type Similar struct {
+	IdolID string `json:"id"`
+	// Distance between centroids of idols' samples.
+	Distance float64 `json:"distance"`
+}
+
+// GetSimilar returns up to limit idols closest to the given one.
+func GetSimilar(ctx context.Context, idolID string, limit int) (similar []Similar, err error) {
+	data, err := getTrainData(ctx)
+	if err != nil {
+		return
+	}
+	return data.similar(idolID, limit)
+}
+
+func (data *trainData) similar(idolID string, limit int) (similar []Similar, err error) {
+	if limit <= 0 {
+		limit = DefaultSimilarLimit
+	} else if limit > MaxSimilarLimit {
+		limit = MaxSimilarLimit
+	}
+	catID, ok := data.cats[idolID]
+	if !ok {
+		err = kpopnet.ErrUnknownIdol
+		return
+	}
+	similar = data.neighbours[catID]
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return
+}
+
+// Find idols closest to the category, done once on train data load.
+func (data *trainData) computeSimilar(catID int32) (similar []Similar) {
+	c := data.centroids[catID]
+	similar = make([]Similar, 0, len(data.centroids)-1)
+	for cat, c2 := range data.centroids {
+		if int32(cat) == catID {
+			continue
+		}
+		similar = append(similar, Similar{
+			IdolID:   data.Labels[cat],
+			Distance: math.Sqrt(face.SquaredEuclideanDistance(c, c2)),
+		})
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		return similar[i].Distance < similar[j].Distance
+	})
+	if len(similar) > MaxSimilarLimit {
+		similar = similar[:MaxSimilarLimit:MaxSimilarLimit]
+	}
+	return
+}
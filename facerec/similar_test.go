@@ -0,0 +1,72 @@
+package facerec
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/Kagami/go-face"
+)
+
+func TestSimilar(t *testing.T) {
+	data := newTrainData(&kpopnet.TrainData{
+		Samples: []face.Descriptor{{0, 0}, {2, 0}, {10, 0}, {4, 0}, {0, 3}},
+		Cats:    []int32{0, 0, 1, 2, 3},
+		Labels:  map[int]string{0: "a", 1: "b", 2: "c", 3: "d"},
+	})
+	if c := data.centroids[0]; c[0] != 1 || c[1] != 0 {
+		t.Errorf("bad centroid: %v", c[:2])
+	}
+	similar, err := data.similar("a", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(similar) != 2 || similar[0] != (Similar{"c", 3}) || similar[1].IdolID != "d" {
+		t.Errorf("bad similar idols: %v", similar)
+	}
+	if similar, _ = data.similar("b", 10); len(similar) != 3 || similar[0].IdolID != "c" {
+		t.Errorf("bad similar idols: %v", similar)
+	}
+	if _, err := data.similar("x", 10); !errors.Is(err, kpopnet.ErrUnknownIdol) {
+		t.Errorf("expected unknown idol: %v", err)
+	}
+}
+
+func TestSimilarLimit(t *testing.T) {
+	n := DefaultSimilarLimit + 10
+	data := &kpopnet.TrainData{Labels: make(map[int]string, n)}
+	for i := 0; i < n; i++ {
+		data.Samples = append(data.Samples, face.Descriptor{float32(i)})
+		data.Cats = append(data.Cats, int32(i))
+		data.Labels[i] = fmt.Sprintf("i%d", i)
+	}
+	td := newTrainData(data)
+	if similar, _ := td.similar("i0", 0); len(similar) != DefaultSimilarLimit {
+		t.Errorf("expected default limit but got %d idols", len(similar))
+	}
+	// Limit above maximum is clamped rather than reset to default.
+	if similar, _ := td.similar("i0", MaxSimilarLimit+1); len(similar) != n-1 {
+		t.Errorf("expected %d idols but got %d", n-1, len(similar))
+	}
+}
+
+func TestSimilarPrecomputed(t *testing.T) {
+	n := MaxSimilarLimit + 5
+	data := &kpopnet.TrainData{Labels: make(map[int]string, n)}
+	for i := 0; i < n; i++ {
+		data.Samples = append(data.Samples, face.Descriptor{float32(i)})
+		data.Cats = append(data.Cats, int32(i))
+		data.Labels[i] = fmt.Sprintf("i%d", i)
+	}
+	td := newTrainData(data)
+	for cat, similar := range td.neighbours {
+		if len(similar) != MaxSimilarLimit {
+			t.Fatalf("%d: expected %d similar idols but got %d", cat, MaxSimilarLimit, len(similar))
+		}
+	}
+	if similar := td.neighbours[0]; similar[0].IdolID != "i1" || similar[1].IdolID != "i2" {
+		t.Errorf("bad similar idols: %v", similar[:2])
+	}
+}
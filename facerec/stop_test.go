@@ -0,0 +1,28 @@
+package facerec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func TestStop(t *testing.T) {
+	defer func() { stopping = false }()
+	// Request which is waiting for worker.
+	active.Add(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := Stop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected stop to wait for active request: %v", err)
+	}
+	active.Done()
+	if err := Stop(context.Background()); err != nil {
+		t.Error(err)
+	}
+	if _, err := RequestRecognize(context.Background(), nil); !errors.Is(err, kpopnet.ErrShuttingDown) {
+		t.Errorf("expected shutting down error: %v", err)
+	}
+}
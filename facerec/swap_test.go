@@ -0,0 +1,82 @@
+package facerec
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/Kagami/go-face"
+)
+
+// Classifies every face as the last category of its samples.
+type lastCatClassifier struct {
+	cats []int32
+}
+
+func (c *lastCatClassifier) SetSamples(samples []face.Descriptor, cats []int32) {
+	c.cats = cats
+}
+
+func (c *lastCatClassifier) Classify(descr face.Descriptor) int {
+	if len(c.cats) == 0 {
+		return -1
+	}
+	return int(c.cats[len(c.cats)-1])
+}
+
+func TestSwapTrainData(t *testing.T) {
+	defer func(c sampleClassifier, data *trainData) {
+		classifier, classifierData = c, data
+	}(classifier, classifierData)
+	classifier = &lastCatClassifier{}
+	small := newTrainData(&kpopnet.TrainData{
+		Samples: []face.Descriptor{{0}},
+		Cats:    []int32{0},
+		Labels:  map[int]string{0: "a"},
+	})
+	big := newTrainData(&kpopnet.TrainData{
+		Samples: []face.Descriptor{{0}, {1}, {2}},
+		Cats:    []int32{0, 1, 2},
+		Labels:  map[int]string{0: "x", 1: "y", 2: "z"},
+	})
+	setSamples(small)
+
+	// Swap train data while recognitions are in flight. Category of one
+	// train data must never be looked up in the other.
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			if i%2 == 0 {
+				setSamples(big)
+			} else {
+				setSamples(small)
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		idolID, _, err := classify(face.Descriptor{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idolID != "a" && idolID != "z" {
+			t.Fatalf("mixed up train data: %q", idolID)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	// Reloaded data is used by the following recognitions.
+	setSamples(big)
+	if idolID, _, _ := classify(face.Descriptor{}); idolID != "z" {
+		t.Errorf("old train data used after reload: %q", idolID)
+	}
+}
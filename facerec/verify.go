@@ -0,0 +1,103 @@
+package facerec
+
+import (
+	"context"
+	"math"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/Kagami/go-face"
+)
+
+// MatchThreshold is the maximum distance between descriptors of the
+// same person.
+const MatchThreshold = 0.6
+
+// Verification is a result of comparing face with another face or with
+// idol's samples.
+type Verification struct {
+	// Whether faces belong to the same person.
+	Match bool `json:"match"`
+	// Distance between the faces or between the face and the closest
+	// sample of the idol.
+	Distance float64 `json:"distance"`
+	// Mean distance to the idol's samples, only set on idol verification.
+	MeanDistance float64 `json:"mean_distance,omitempty"`
+	// Number of idol's samples compared against.
+	Samples   int     `json:"samples,omitempty"`
+	Threshold float64 `json:"threshold"`
+}
+
+func newVerification(dist float64) *Verification {
+	return &Verification{
+		Match:     dist <= MatchThreshold,
+		Distance:  dist,
+		Threshold: MatchThreshold,
+	}
+}
+
+// RequestVerify checks whether two images show the same person.
+func RequestVerify(ctx context.Context, imgData1 []byte, imgData2 []byte) (v *Verification, err error) {
+	ch := make(chan recResult, 1)
+	go func() {
+		ch <- request(ctx, imgData2, true)
+	}()
+	r1 := request(ctx, imgData1, true)
+	r2 := <-ch
+	if r1.err != nil {
+		return nil, r1.err
+	}
+	if r2.err != nil {
+		return nil, r2.err
+	}
+	v = verifyFaces(r1.descr, r2.descr)
+	return
+}
+
+// Compare two face descriptors.
+func verifyFaces(descr1, descr2 face.Descriptor) *Verification {
+	return newVerification(math.Sqrt(face.SquaredEuclideanDistance(descr1, descr2)))
+}
+
+// RequestVerifyIdol checks whether image shows the given idol.
+func RequestVerifyIdol(ctx context.Context, imgData []byte, idolID string) (v *Verification, err error) {
+	data, err := getTrainData(ctx)
+	if err != nil {
+		return
+	}
+	// Check before the expensive recognition.
+	if _, ok := data.cats[idolID]; !ok {
+		err = kpopnet.ErrUnknownIdol
+		return
+	}
+	r := request(ctx, imgData, true)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return data.verifyIdol(r.descr, idolID)
+}
+
+// Compare face descriptor with the idol's samples.
+func (data *trainData) verifyIdol(descr face.Descriptor, idolID string) (v *Verification, err error) {
+	catID, ok := data.cats[idolID]
+	if !ok {
+		err = kpopnet.ErrUnknownIdol
+		return
+	}
+	min := math.Inf(1)
+	sum := 0.0
+	n := 0
+	for i, cat := range data.Cats {
+		if cat != catID {
+			continue
+		}
+		dist := math.Sqrt(face.SquaredEuclideanDistance(data.Samples[i], descr))
+		min = math.Min(min, dist)
+		sum += dist
+		n++
+	}
+	v = newVerification(min)
+	v.MeanDistance = sum / float64(n)
+	v.Samples = n
+	return
+}
@@ -0,0 +1,46 @@
+package facerec
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/Kagami/go-face"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestVerifyFaces(t *testing.T) {
+	v := verifyFaces(face.Descriptor{0, 0}, face.Descriptor{0.3, 0.4})
+	if !v.Match || !almostEqual(v.Distance, 0.5) || v.Threshold != MatchThreshold {
+		t.Errorf("bad verification: %+v", v)
+	}
+	if v = verifyFaces(face.Descriptor{0}, face.Descriptor{1}); v.Match {
+		t.Errorf("distant faces match: %+v", v)
+	}
+}
+
+func TestVerifyIdol(t *testing.T) {
+	data := newTrainData(&kpopnet.TrainData{
+		Samples: []face.Descriptor{{0.2}, {0.4}, {0.9}, {3}},
+		Cats:    []int32{0, 0, 0, 1},
+		Labels:  map[int]string{0: "a", 1: "b"},
+	})
+	v, err := data.verifyIdol(face.Descriptor{0}, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Match || !almostEqual(v.Distance, 0.2) || !almostEqual(v.MeanDistance, 0.5) || v.Samples != 3 {
+		t.Errorf("bad verification: %+v", v)
+	}
+	if v, _ = data.verifyIdol(face.Descriptor{0}, "b"); v.Match || !almostEqual(v.Distance, 3) || v.Samples != 1 {
+		t.Errorf("bad verification: %+v", v)
+	}
+	if _, err := data.verifyIdol(face.Descriptor{0}, "x"); !errors.Is(err, kpopnet.ErrUnknownIdol) {
+		t.Errorf("expected unknown idol: %v", err)
+	}
+}
@@ -5,8 +5,10 @@ go 1.14
 require (
 	github.com/BurntSushi/toml v0.3.1
 	github.com/Kagami/go-face v0.0.0-20200508235642-fd24bba43a1f
+	github.com/andybalholm/brotli v1.0.4
 	github.com/dimfeld/httptreemux/v5 v5.2.2
 	github.com/docopt/docopt-go v0.0.0-20180111231733-ee0de3bc6815
 	github.com/kevinburke/go-bindata v3.19.0+incompatible
 	github.com/lib/pq v1.5.2
+	github.com/prometheus/client_golang v1.12.2
 )
@@ -0,0 +1,210 @@
+// Package logging provides leveled structured logger. Lines are written
+// either in logfmt or JSON format and include ID of the request which
+// caused them if it's stored in the context.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is a log level.
+type Level int
+
+// Available log levels.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns level by its name.
+func ParseLevel(name string) (Level, error) {
+	for l, n := range levelNames {
+		if n == strings.ToLower(name) {
+			return Level(l), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// Options of the logger.
+type Options struct {
+	// Minimal level of logged lines, info by default.
+	Level string
+	// Either logfmt (default) or json.
+	Format string
+	// Stderr by default.
+	Output io.Writer
+}
+
+var (
+	mu     sync.Mutex
+	level            = LevelInfo
+	asJSON           = false
+	output io.Writer = os.Stderr
+)
+
+// Setup configures the logger.
+func Setup(opts Options) (err error) {
+	l := LevelInfo
+	if opts.Level != "" {
+		if l, err = ParseLevel(opts.Level); err != nil {
+			return
+		}
+	}
+	var j bool
+	switch opts.Format {
+	case "", "logfmt":
+	case "json":
+		j = true
+	default:
+		return fmt.Errorf("unknown log format %q", opts.Format)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+	asJSON = j
+	if opts.Output != nil {
+		output = opts.Output
+	}
+	return
+}
+
+type requestIDKey struct{}
+
+// NewRequestID generates random request ID.
+func NewRequestID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// WithRequestID returns copy of the context with provided request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns ID of the request stored in the context.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Debug logs message with key-value pairs at debug level.
+func Debug(ctx context.Context, msg string, kv ...interface{}) {
+	write(ctx, LevelDebug, msg, kv)
+}
+
+// Info logs message with key-value pairs at info level.
+func Info(ctx context.Context, msg string, kv ...interface{}) {
+	write(ctx, LevelInfo, msg, kv)
+}
+
+// Warn logs message with key-value pairs at warn level.
+func Warn(ctx context.Context, msg string, kv ...interface{}) {
+	write(ctx, LevelWarn, msg, kv)
+}
+
+// Error logs message with key-value pairs at error level.
+func Error(ctx context.Context, msg string, kv ...interface{}) {
+	write(ctx, LevelError, msg, kv)
+}
+
+// Fatal logs message at error level and exits.
+func Fatal(ctx context.Context, msg string, kv ...interface{}) {
+	write(ctx, LevelError, msg, kv)
+	os.Exit(1)
+}
+
+func write(ctx context.Context, l Level, msg string, kv []interface{}) {
+	mu.Lock()
+	defer mu.Unlock()
+	if l < level {
+		return
+	}
+	fields := []interface{}{
+		"time", time.Now().UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		"level", l.String(),
+		"msg", msg,
+	}
+	if ctx != nil {
+		if id := RequestID(ctx); id != "" {
+			fields = append(fields, "request_id", id)
+		}
+	}
+	fields = append(fields, kv...)
+	if len(fields)%2 != 0 {
+		fields = append(fields, nil)
+	}
+	var buf bytes.Buffer
+	if asJSON {
+		encodeJSON(&buf, fields)
+	} else {
+		encodeLogfmt(&buf, fields)
+	}
+	output.Write(buf.Bytes())
+}
+
+// Make value printable.
+func format(v interface{}) interface{} {
+	switch v := v.(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return v
+}
+
+func encodeJSON(buf *bytes.Buffer, fields []interface{}) {
+	buf.WriteByte('{')
+	for i := 0; i < len(fields); i += 2 {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(fmt.Sprint(fields[i]))
+		buf.Write(key)
+		buf.WriteByte(':')
+		val, err := json.Marshal(format(fields[i+1]))
+		if err != nil {
+			val, _ = json.Marshal(fmt.Sprint(fields[i+1]))
+		}
+		buf.Write(val)
+	}
+	buf.WriteString("}\n")
+}
+
+func encodeLogfmt(buf *bytes.Buffer, fields []interface{}) {
+	for i := 0; i < len(fields); i += 2 {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(fmt.Sprint(fields[i]))
+		buf.WriteByte('=')
+		val := fmt.Sprint(format(fields[i+1]))
+		if val == "" || strings.ContainsAny(val, " =\"\\\n\t") {
+			val = fmt.Sprintf("%q", val)
+		}
+		buf.WriteString(val)
+	}
+	buf.WriteByte('\n')
+}
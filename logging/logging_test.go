@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func setupTest(t *testing.T, opts Options) *bytes.Buffer {
+	var buf bytes.Buffer
+	opts.Output = &buf
+	if err := Setup(opts); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestLogfmt(t *testing.T) {
+	defer Setup(Options{Output: os.Stderr})
+	buf := setupTest(t, Options{Level: "warn"})
+	ctx := WithRequestID(context.Background(), "abc")
+	Info(ctx, "skipped")
+	Warn(ctx, "bad thing", "error", errors.New("db is down"), "n", 3)
+	line := buf.String()
+	if strings.Count(line, "\n") != 1 {
+		t.Fatalf("expected single line but got %q", line)
+	}
+	expected := ` level=warn msg="bad thing" request_id=abc error="db is down" n=3` + "\n"
+	if !strings.HasSuffix(line, expected) {
+		t.Errorf("unexpected line %q", line)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	defer Setup(Options{Output: os.Stderr})
+	buf := setupTest(t, Options{Level: "debug", Format: "json"})
+	Debug(context.Background(), "request", "status", 200, "odd")
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["level"] != "debug" || fields["msg"] != "request" ||
+		fields["status"] != 200.0 || fields["odd"] != nil || fields["request_id"] != nil {
+		t.Errorf("unexpected fields %v", fields)
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	defer Setup(Options{Output: os.Stderr})
+	if err := Setup(Options{Level: "verbose"}); err == nil {
+		t.Error("expected bad level error")
+	}
+	if err := Setup(Options{Format: "xml"}); err == nil {
+		t.Error("expected bad format error")
+	}
+}
@@ -0,0 +1,122 @@
+// Package metrics exposes kpopnet internals in Prometheus format.
+package metrics
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kpopnet/go-kpopnet"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kpopnet"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	// Requests counts served API requests.
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of served API requests.",
+	}, []string{"route", "method", "status"})
+
+	// RequestDuration measures time spent on API requests.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time spent on API requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route"})
+
+	// Recognitions measures time spent by recognizer workers. Type is
+	// either recognize or detect, outcome is error code or success.
+	Recognitions = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "recognition_duration_seconds",
+		Help:      "Time spent on face recognition.",
+		Buckets:   []float64{.05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"type", "outcome"})
+
+	// RecognitionQueue is a number of requests waiting for recognizer.
+	RecognitionQueue = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "recognition_queue_length",
+		Help:      "Number of requests waiting for recognizer.",
+	})
+
+	// RateLimited counts requests rejected by rate limiter. Kind is either
+	// key or ip.
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Number of requests rejected by rate limiter.",
+	}, []string{"kind"})
+
+	// CacheRequests counts cache lookups by key and result (hit/miss).
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache lookups.",
+	}, []string{"key", "result"})
+
+	// DBQueryDuration measures time spent on DB queries by query ID, which
+	// is name of the SQL file.
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Time spent on DB queries.",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"query"})
+
+	// TrainSamples is a number of confirmed face descriptors in the
+	// loaded train data.
+	TrainSamples = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "train_samples",
+		Help:      "Number of samples in loaded train data.",
+	})
+
+	// TrainIdols is a number of idols in the loaded train data.
+	TrainIdols = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "train_idols",
+		Help:      "Number of idols in loaded train data.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		Requests,
+		RequestDuration,
+		Recognitions,
+		RecognitionQueue,
+		RateLimited,
+		CacheRequests,
+		DBQueryDuration,
+		TrainSamples,
+		TrainIdols,
+	)
+}
+
+// Outcome returns label value describing result of the operation: API
+// error code, generic "error" or "success".
+func Outcome(err error) string {
+	if err == nil {
+		return "success"
+	}
+	var apiErr *kpopnet.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return "error"
+}
+
+// Handler serves all metrics.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
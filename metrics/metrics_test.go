@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := map[error]string{
+		nil:                   "success",
+		kpopnet.ErrNoIdol:     "no_idol",
+		kpopnet.ErrBadImage:   "bad_image",
+		errors.New("db down"): "error",
+		fmt.Errorf("wrapped: %w", kpopnet.ErrNoSingleFace): "no_single_face",
+	}
+	for err, expected := range tests {
+		if actual := Outcome(err); actual != expected {
+			t.Errorf("%v: expected %s but got %s", err, expected, actual)
+		}
+	}
+}
@@ -1,23 +1,76 @@
 package kpopnet
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/Kagami/go-face"
 )
 
 const (
 	indexName = "index"
+
+	// DateLayout is a format of all dates in profile data.
+	DateLayout = "2006-01-02"
 )
 
 // Band info.
-type Band map[string]interface{}
+type Band struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	AltNames    []string `json:"alt_names,omitempty"`
+	KoreanName  string   `json:"korean_name,omitempty"`
+	AgencyName  string   `json:"agency_name,omitempty"`
+	DebutDate   string   `json:"debut_date,omitempty"`
+	DisbandDate string   `json:"disband_date,omitempty"`
+	URLs        []string `json:"urls,omitempty"`
+	// Extra keeps unknown data fields as is.
+	Extra map[string]interface{} `json:"-"`
+}
 
 // Idol info.
-type Idol map[string]interface{}
+type Idol struct {
+	ID         string   `json:"id"`
+	BandID     string   `json:"band_id"`
+	ImageID    string   `json:"image_id,omitempty"`
+	Name       string   `json:"name"`
+	AltNames   []string `json:"alt_names,omitempty"`
+	BirthName  string   `json:"birth_name,omitempty"`
+	KoreanName string   `json:"korean_name,omitempty"`
+	BirthDate  string   `json:"birth_date,omitempty"`
+	DebutDate  string   `json:"debut_date,omitempty"`
+	Height     float64  `json:"height,omitempty"`
+	Weight     float64  `json:"weight,omitempty"`
+	Positions  []string `json:"positions,omitempty"`
+	URLs       []string `json:"urls,omitempty"`
+	// Extra keeps unknown data fields as is.
+	Extra map[string]interface{} `json:"-"`
+}
 
-// Profiles contains information about known bands and idols.
+// Membership describes idol's participation in a band. Idol's BandID
+// always points to the primary band.
+type Membership struct {
+	IdolID    string `json:"idol_id"`
+	BandID    string `json:"band_id"`
+	Role      string `json:"role,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// Profiles contains information about known bands and idols. In case
+// of incremental sync it contains only entities changed after some
+// revision plus IDs of deleted ones.
 type Profiles struct {
-	Bands []Band `json:"bands"`
-	Idols []Idol `json:"idols"`
+	Revision     int64         `json:"revision"`
+	Bands        []*Band       `json:"bands"`
+	Idols        []*Idol       `json:"idols"`
+	Memberships  []*Membership `json:"memberships"`
+	DeletedBands []string      `json:"deleted_bands,omitempty"`
+	DeletedIdols []string      `json:"deleted_idols,omitempty"`
 }
 
 // TrainData contains information about all recognized idols.
@@ -26,3 +79,130 @@ type TrainData struct {
 	Cats    []int32
 	Labels  map[int]string
 }
+
+// Face is a stored face descriptor with the idol it's labelled with.
+type Face struct {
+	ID         int64
+	ImageID    string
+	IdolID     string
+	Descriptor face.Descriptor
+}
+
+// Avoid recursion in custom (un)marshalers.
+type bandFields Band
+type idolFields Idol
+
+// MarshalJSON encodes band together with its extra fields.
+func (b *Band) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra((*bandFields)(b), b.Extra)
+}
+
+// UnmarshalJSON decodes band, saving unknown fields to Extra.
+func (b *Band) UnmarshalJSON(data []byte) (err error) {
+	b.Extra, err = unmarshalWithExtra(data, (*bandFields)(b))
+	return
+}
+
+// Validate checks that band info is consistent.
+func (b *Band) Validate() error {
+	if err := validateInfo(b.Name, b.DebutDate, b.DisbandDate); err != nil {
+		return fmt.Errorf("invalid band %s: %v", b.ID, err)
+	}
+	return nil
+}
+
+// MarshalJSON encodes idol together with its extra fields.
+func (i *Idol) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra((*idolFields)(i), i.Extra)
+}
+
+// UnmarshalJSON decodes idol, saving unknown fields to Extra.
+func (i *Idol) UnmarshalJSON(data []byte) (err error) {
+	i.Extra, err = unmarshalWithExtra(data, (*idolFields)(i))
+	return
+}
+
+// Validate checks that idol info is consistent.
+func (i *Idol) Validate() error {
+	err := validateInfo(i.Name, i.BirthDate, i.DebutDate)
+	if err == nil && (i.Height < 0 || i.Weight < 0) {
+		err = errors.New("negative height/weight")
+	}
+	if err != nil {
+		return fmt.Errorf("invalid idol %s: %v", i.ID, err)
+	}
+	return nil
+}
+
+func validateInfo(name string, dates ...string) error {
+	if name == "" {
+		return errors.New("empty name")
+	}
+	for _, date := range dates {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse(DateLayout, date); err != nil {
+			return fmt.Errorf("bad date %q", date)
+		}
+	}
+	return nil
+}
+
+// Helpers for structs with extra fields.
+
+func marshalWithExtra(v interface{}, extra map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	fields := make(map[string]json.RawMessage, len(extra))
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, val := range extra {
+		// Known fields always take precedence.
+		if _, ok := fields[key]; ok {
+			continue
+		}
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = raw
+	}
+	return json.Marshal(fields)
+}
+
+func unmarshalWithExtra(data []byte, v interface{}) (extra map[string]interface{}, err error) {
+	if err = json.Unmarshal(data, v); err != nil {
+		return
+	}
+	var fields map[string]interface{}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	for key, val := range fields {
+		if known[key] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]interface{})
+		}
+		extra[key] = val
+	}
+	return
+}
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		name := strings.Split(tag, ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
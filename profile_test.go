@@ -0,0 +1,59 @@
+package kpopnet
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIdolJSON(t *testing.T) {
+	in := `{"name":"Chaeyoung","birth_date":"1999-04-23","height":159,"positions":["Rapper"],"mbti":"INFP"}`
+	var idol Idol
+	if err := json.Unmarshal([]byte(in), &idol); err != nil {
+		t.Fatal(err)
+	}
+	if idol.Name != "Chaeyoung" || idol.Height != 159 || len(idol.Positions) != 1 {
+		t.Errorf("bad known fields: %+v", idol)
+	}
+	if idol.Extra["mbti"] != "INFP" {
+		t.Errorf("extra field not preserved: %v", idol.Extra)
+	}
+	if err := idol.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	idol.ID = "1"
+	idol.BandID = "2"
+	out, err := json.Marshal(&idol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(out, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"id", "band_id", "name", "birth_date", "mbti"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("missing %q in %s", key, out)
+		}
+	}
+	if _, ok := fields["image_id"]; ok {
+		t.Errorf("empty image_id shouldn't be encoded: %s", out)
+	}
+}
+
+func TestIdolValidate(t *testing.T) {
+	bad := []Idol{
+		{},
+		{Name: "Joy", BirthDate: "1996/09/03"},
+		{Name: "Joy", Height: -1},
+	}
+	for _, idol := range bad {
+		if err := idol.Validate(); err == nil {
+			t.Errorf("expected error for %+v", idol)
+		}
+	}
+	var idol Idol
+	if err := json.Unmarshal([]byte(`{"name":"Joy","height":"tall"}`), &idol); err == nil {
+		t.Error("expected error for malformed height")
+	}
+}
@@ -0,0 +1,33 @@
+package search
+
+// Hangul syllables are composed algorithmically from lead consonant,
+// vowel and optional tail consonant, see Unicode standard, chapter 3.12.
+const (
+	hangulBase  = 0xAC00
+	hangulLast  = 0xD7A3
+	numVowels   = 21
+	numTails    = 28
+	numPerLead  = numVowels * numTails
+	noTailIndex = 0
+)
+
+// Compatibility jamo are used so that separately typed letters (e.g.
+// "ㅊ") match decomposed syllables.
+var (
+	leads  = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+	vowels = []rune("ㅏㅐㅑㅒㅓㅔㅕㅖㅗㅘㅙㅚㅛㅜㅝㅞㅟㅠㅡㅢㅣ")
+	tails  = []rune(" ㄱㄲㄳㄴㄵㄶㄷㄹㄺㄻㄼㄽㄾㄿㅀㅁㅂㅄㅅㅆㅇㅈㅊㅋㅌㅍㅎ")
+)
+
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+func appendJamo(out []rune, r rune) []rune {
+	idx := int(r - hangulBase)
+	out = append(out, leads[idx/numPerLead], vowels[idx%numPerLead/numTails])
+	if tail := idx % numTails; tail != noTailIndex {
+		out = append(out, tails[tail])
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+// Package search implements in-memory fuzzy search over idol and band
+// profiles.
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+const (
+	// DefaultLimit is a number of results returned if limit isn't set.
+	DefaultLimit = 20
+	// MaxLimit is a maximum number of results per query.
+	MaxLimit = 100
+)
+
+// Scores of different match kinds, higher is better.
+const (
+	exactScore  = 1.0
+	prefixScore = 0.8
+	fuzzyScore  = 0.5
+)
+
+// Result is a single search hit. Only one of Idol/Band is set.
+type Result struct {
+	Score float64       `json:"score"`
+	Idol  *kpopnet.Idol `json:"idol,omitempty"`
+	Band  *kpopnet.Band `json:"band,omitempty"`
+}
+
+type entry struct {
+	name  string
+	idol  *kpopnet.Idol
+	band  *kpopnet.Band
+	terms [][]rune
+}
+
+// Index is an immutable search index, safe for concurrent use.
+type Index struct {
+	entries []entry
+}
+
+// NewIndex builds search index over provided profiles.
+func NewIndex(ps *kpopnet.Profiles) *Index {
+	idx := &Index{entries: make([]entry, 0, len(ps.Bands)+len(ps.Idols))}
+	for _, band := range ps.Bands {
+		names := append([]string{band.Name, band.KoreanName}, band.AltNames...)
+		idx.add(entry{name: band.Name, band: band}, names)
+	}
+	for _, idol := range ps.Idols {
+		names := append([]string{idol.Name, idol.KoreanName, idol.BirthName}, idol.AltNames...)
+		idx.add(entry{name: idol.Name, idol: idol}, names)
+	}
+	return idx
+}
+
+func (idx *Index) add(e entry, names []string) {
+	for _, name := range names {
+		if term := normalize(name); len(term) > 0 {
+			e.terms = append(e.terms, term)
+		}
+	}
+	if len(e.terms) > 0 {
+		idx.entries = append(idx.entries, e)
+	}
+}
+
+// Search returns up to limit best matches for the query. Query may be
+// romanized, in Hangul (including incomplete syllables) and contain
+// typos.
+func (idx *Index) Search(query string, limit int) []Result {
+	results := make([]Result, 0)
+	q := normalize(query)
+	if len(q) == 0 {
+		return results
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	} else if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	type hit struct {
+		score float64
+		e     *entry
+	}
+	var hits []hit
+	for i := range idx.entries {
+		e := &idx.entries[i]
+		best := 0.0
+		for _, term := range e.terms {
+			if score := match(q, term); score > best {
+				best = score
+			}
+		}
+		if best > 0 {
+			hits = append(hits, hit{best, e})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].e.name < hits[j].e.name
+	})
+	for i := 0; i < len(hits) && i < limit; i++ {
+		results = append(results, Result{
+			Score: hits[i].score,
+			Idol:  hits[i].e.idol,
+			Band:  hits[i].e.band,
+		})
+	}
+	return results
+}
+
+// Score query against single term, 0 means no match.
+func match(q, term []rune) float64 {
+	if equal(q, term) {
+		return exactScore
+	}
+	if len(q) < len(term) && equal(q, term[:len(q)]) {
+		// Prefer terms which are closer in length.
+		return prefixScore - 0.1*float64(len(term)-len(q))/float64(len(term))
+	}
+	maxDist := maxDistance(len(q))
+	if maxDist == 0 {
+		return 0
+	}
+	dist := levenshtein(q, term)
+	// Allow typos in yet incomplete input as well.
+	if len(q) < len(term) {
+		if d := levenshtein(q, term[:len(q)]); d < dist {
+			dist = d
+		}
+	}
+	if dist > maxDist {
+		return 0
+	}
+	return fuzzyScore - 0.1*float64(dist)
+}
+
+// Short queries should match precisely, otherwise there would be too
+// many results.
+func maxDistance(n int) int {
+	switch {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func equal(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// Lowercase, drop spaces/punctuation and decompose Hangul syllables to
+// jamo so "Mei Qi" matches "meiqi" and "챙" matches "채영".
+func normalize(s string) []rune {
+	var out []rune
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case isHangulSyllable(r):
+			out = appendJamo(out, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func testIndex() *Index {
+	return NewIndex(&kpopnet.Profiles{
+		Bands: []*kpopnet.Band{
+			{ID: "b1", Name: "Twice", KoreanName: "트와이스"},
+			{ID: "b2", Name: "WJSN", AltNames: []string{"Cosmic Girls"}},
+		},
+		Idols: []*kpopnet.Idol{
+			{ID: "i1", BandID: "b1", Name: "Chaeyoung", KoreanName: "채영"},
+			{ID: "i2", BandID: "b1", Name: "Tzuyu", KoreanName: "쯔위"},
+			{ID: "i3", BandID: "b2", Name: "Mei Qi", KoreanName: "미기"},
+			{ID: "i4", BandID: "b2", Name: "Luda", KoreanName: "루다"},
+		},
+	})
+}
+
+func TestSearch(t *testing.T) {
+	idx := testIndex()
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"chaeyoung", "i1"},
+		{"Chae", "i1"},
+		{"chaeyuong", "i1"},
+		{"채영", "i1"},
+		{"챙", "i1"},
+		{"ㅊㅐ", "i1"},
+		{"meiqi", "i3"},
+		{"mei-qi", "i3"},
+		{"cosmic", "b2"},
+		{"트와", "b1"},
+	}
+	for _, tt := range tests {
+		results := idx.Search(tt.query, 0)
+		if len(results) == 0 {
+			t.Errorf("%q: no results", tt.query)
+			continue
+		}
+		var id string
+		if r := results[0]; r.Idol != nil {
+			id = r.Idol.ID
+		} else {
+			id = r.Band.ID
+		}
+		if id != tt.expected {
+			t.Errorf("%q: expected %s but got %s", tt.query, tt.expected, id)
+		}
+	}
+}
+
+func TestSearchNoResults(t *testing.T) {
+	idx := testIndex()
+	for _, query := range []string{"", " ", "xyz", "yuna"} {
+		if results := idx.Search(query, 0); len(results) != 0 {
+			t.Errorf("%q: expected no results but got %d", query, len(results))
+		}
+	}
+}
+
+func TestSearchOrder(t *testing.T) {
+	results := testIndex().Search("Luda", 10)
+	if len(results) == 0 || results[0].Idol == nil || results[0].Idol.ID != "i4" {
+		t.Fatalf("exact match should be first: %+v", results)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Score > results[i-1].Score {
+			t.Errorf("results aren't sorted by score")
+		}
+	}
+}
+
+func TestSearchLimit(t *testing.T) {
+	ps := &kpopnet.Profiles{}
+	for i := 0; i < DefaultLimit+10; i++ {
+		ps.Idols = append(ps.Idols, &kpopnet.Idol{
+			ID:   fmt.Sprintf("i%d", i),
+			Name: fmt.Sprintf("Idol %d", i),
+		})
+	}
+	idx := NewIndex(ps)
+	if results := idx.Search("Idol", 0); len(results) != DefaultLimit {
+		t.Errorf("expected default limit but got %d results", len(results))
+	}
+	// Limit above maximum is clamped rather than reset to default.
+	if results := idx.Search("Idol", MaxLimit+1); len(results) != len(ps.Idols) {
+		t.Errorf("expected %d results but got %d", len(ps.Idols), len(results))
+	}
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/cache"
+	"github.com/kpopnet/go-kpopnet/db"
+	"github.com/kpopnet/go-kpopnet/facerec"
+)
+
+const defaultMaxAdminFaces = 5000
+
+func getMaxAdminFaces() int {
+	if n := getOptions().MaxAdminFaces; n > 0 {
+		return n
+	}
+	return defaultMaxAdminFaces
+}
+
+// Wrap handler of admin API which is available only if enabled in
+// config.
+func adminOnly(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !getOptions().EnableAdmin {
+			serveError(w, r, kpopnet.ErrNotEnabled)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// ServeAuditFaces returns a JSON report with suspicious confirmed faces,
+// see facerec.AuditFaces. Large sets of faces should be audited with CLI.
+func ServeAuditFaces(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := facerec.AuditOptions{MaxFaces: getMaxAdminFaces()}
+	var err error
+	if s := query.Get("neighbours"); s != "" {
+		if opts.Neighbours, err = strconv.Atoi(s); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	if s := query.Get("duplicate_distance"); s != "" {
+		if opts.DuplicateDistance, err = strconv.ParseFloat(s, 64); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	if s := query.Get("min_samples"); s != "" {
+		if opts.MinSamples, err = strconv.Atoi(s); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	report, err := facerec.AuditFaces(r.Context(), opts)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, report)
+}
+
+// ServeClusterFaces returns a JSON report with clusters of unconfirmed
+// faces, see facerec.ClusterFaces. Large sets of faces should be
+// clustered with CLI.
+func ServeClusterFaces(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := facerec.ClusterOptions{MaxFaces: getMaxAdminFaces()}
+	var err error
+	if s := query.Get("distance"); s != "" {
+		if opts.Distance, err = strconv.ParseFloat(s, 64); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	if s := query.Get("min_size"); s != "" {
+		if opts.MinSize, err = strconv.Atoi(s); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	report, err := facerec.ClusterFaces(r.Context(), opts)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, report)
+}
+
+// Request to label faces as existing or new idol.
+type labelRequest struct {
+	FaceIDs []int64       `json:"face_ids"`
+	IdolID  string        `json:"idol_id"`
+	Idol    *kpopnet.Idol `json:"idol"`
+}
+
+type labelResponse struct {
+	Idol     *kpopnet.Idol `json:"idol,omitempty"`
+	IdolID   string        `json:"idol_id"`
+	Labelled int64         `json:"labelled"`
+}
+
+// ServeLabelFaces confirms unconfirmed faces (e.g. a whole cluster) as
+// existing idol or as the new one created from provided info.
+func ServeLabelFaces(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxOverheadSize*10)
+	var req labelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		serveError(w, r, kpopnet.ErrParseJSON)
+		return
+	}
+	if len(req.FaceIDs) == 0 || (req.IdolID == "") == (req.Idol == nil) {
+		serveError(w, r, kpopnet.ErrParseJSON)
+		return
+	}
+	idol := req.Idol
+	if idol == nil {
+		idol = &kpopnet.Idol{ID: req.IdolID}
+	} else {
+		// ID of the new idol is generated.
+		idol.ID = ""
+		if err := idol.Validate(); err != nil {
+			serveError(w, r, kpopnet.ErrParseJSON)
+			return
+		}
+	}
+	n, err := db.LabelFaces(r.Context(), idol, req.FaceIDs)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	cache.ClearProfilesCache()
+	cache.ClearTrainDataCache()
+	res := labelResponse{IdolID: idol.ID, Labelled: n}
+	if req.Idol != nil {
+		res.Idol = idol
+	}
+	serveJSON(w, r, res)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminNotEnabled(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.EnableAdmin = false
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/admin/faces/audit", nil)
+	createRouter().ServeHTTP(rec, req)
+	if rec.Code != 501 {
+		t.Errorf("unexpected status %d", rec.Code)
+	}
+}
+
+func TestServeLabelFacesBadRequest(t *testing.T) {
+	defer fakeAPIKeys(testKeys)()
+	defer func(opts Options) { options = opts }(options)
+	options.EnableAdmin = true
+	tests := []string{
+		`{"idol_id": "x"}`,
+		`{"face_ids": [1]}`,
+		`{"face_ids": [1], "idol_id": "x", "idol": {"name": "A"}}`,
+		`{"face_ids": [1], "idol": {"band_id": "x"}}`,
+		`{"face_ids": [1], "idol": {"name": "A", "birth_date": "bad"}}`,
+	}
+	for _, body := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/admin/faces/label", strings.NewReader(body))
+		req.Header.Set("X-API-Key", "admin")
+		createRouter().ServeHTTP(rec, req)
+		if rec.Code != 400 {
+			t.Errorf("%s: unexpected status %d", body, rec.Code)
+		}
+	}
+}
+
+func TestAdminRequiresScope(t *testing.T) {
+	defer fakeAPIKeys(testKeys)()
+	defer func(opts Options) { options = opts }(options)
+	options.EnableAdmin = true
+	for secret, status := range map[string]int{"": 401, "reader": 403} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/admin/faces/audit", nil)
+		if secret != "" {
+			req.Header.Set("X-API-Key", secret)
+		}
+		createRouter().ServeHTTP(rec, req)
+		if rec.Code != status {
+			t.Errorf("%q: unexpected status %d", secret, rec.Code)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"mime"
 	"net/http"
+	"strconv"
 
 	"github.com/kpopnet/go-kpopnet"
 	"github.com/kpopnet/go-kpopnet/cache"
 	"github.com/kpopnet/go-kpopnet/db"
 	"github.com/kpopnet/go-kpopnet/facerec"
+	"github.com/kpopnet/go-kpopnet/search"
 )
 
 const (
@@ -16,58 +20,236 @@ const (
 	maxBodySize     = maxFileSize + maxOverheadSize
 )
 
+// Get all profiles, cached.
+func getProfiles(ctx context.Context) (ps *kpopnet.Profiles, err error) {
+	v, err := cache.Cached(cache.ProfileDataCacheKey, func() (interface{}, error) {
+		return db.GetProfiles(ctx)
+	})
+	if err != nil {
+		return
+	}
+	ps = v.(*kpopnet.Profiles)
+	return
+}
+
 // ServeProfiles returns a JSON object with information about all profiles.
+// If since revision is passed, only changes after it are returned.
+// Profiles can be also filtered, sorted and paginated, see profileQuery.
 func ServeProfiles(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var ps *kpopnet.Profiles
+	var err error
+	if s := query.Get("since"); s != "" {
+		since, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || since < 0 {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+		if ps, err = db.GetProfilesSince(r.Context(), since); err != nil {
+			serveError(w, r, err)
+			return
+		}
+	}
+	if isPageQuery(query) {
+		serveProfilesPage(w, r, ps)
+		return
+	}
+	if ps != nil {
+		serveJSON(w, r, ps)
+		return
+	}
 	// TODO(Kagami): For some reason cached request is not fast enough.
 	// TODO(Kagami): Use some trigger to invalidate cache.
-	v, err := cache.Cached(cache.ProfileCacheKey, func() (v interface{}, err error) {
-		ps, err := db.GetProfiles()
+	if ps, err = getProfiles(r.Context()); err != nil {
+		serveError(w, r, err)
+		return
+	}
+	v, err := cache.Cached(cache.ProfileCacheKey, func() (interface{}, error) {
+		// Takes ~5ms so better to store encoded.
+		data, err := json.Marshal(ps)
 		if err != nil {
+			return nil, err
+		}
+		return newEncodedJSON(data)
+	})
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveEncodedVariant(w, r, v.(*encodedJSON))
+}
+
+// Serve page of provided profiles or of all profiles if nil.
+func serveProfilesPage(w http.ResponseWriter, r *http.Request, ps *kpopnet.Profiles) {
+	q, err := parseProfileQuery(r.URL.Query())
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	all, err := getProfiles(r.Context())
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	if ps == nil {
+		ps = all
+	}
+	bandByID := make(map[string]*kpopnet.Band, len(all.Bands))
+	for _, band := range all.Bands {
+		bandByID[band.ID] = band
+	}
+	page, err := q.apply(ps, bandByID)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, page)
+}
+
+// ServeIdol returns a JSON object with information about single idol.
+func ServeIdol(w http.ResponseWriter, r *http.Request) {
+	idol, err := db.GetIdol(r.Context(), getParam(r, "id"))
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, idol)
+}
+
+// ServeSimilarIdols returns a JSON array with idols which look like the
+// given one, closest first.
+func ServeSimilarIdols(w http.ResponseWriter, r *http.Request) {
+	limit := facerec.DefaultSimilarLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
 			return
 		}
-		// Takes ~5ms so better to store encoded.
-		return json.Marshal(ps)
+	}
+	similar, err := facerec.GetSimilar(r.Context(), getParam(r, "id"), limit)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, similar)
+}
+
+// ServeBand returns a JSON object with information about single band.
+func ServeBand(w http.ResponseWriter, r *http.Request) {
+	band, err := db.GetBand(r.Context(), getParam(r, "id"))
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, band)
+}
+
+// ServeBandIdols returns a JSON array with all members of the band.
+func ServeBandIdols(w http.ResponseWriter, r *http.Request) {
+	idols, err := db.GetBandIdols(r.Context(), getParam(r, "id"))
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, idols)
+}
+
+// ServeSearch returns a JSON array with idols and bands matching the
+// query.
+func ServeSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := query.Get("q")
+	if q == "" {
+		serveError(w, r, kpopnet.ErrBadQuery)
+		return
+	}
+	limit := search.DefaultLimit
+	if s := query.Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil {
+			serveError(w, r, kpopnet.ErrBadQuery)
+			return
+		}
+	}
+	ps, err := getProfiles(r.Context())
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	v, err := cache.Cached(cache.SearchIndexCacheKey, func() (interface{}, error) {
+		return search.NewIndex(ps), nil
 	})
 	if err != nil {
-		serve500(w, r, err)
+		serveError(w, r, err)
 		return
 	}
-	serveEncodedJSON(w, r, v.([]byte))
+	idx := v.(*search.Index)
+	serveJSON(w, r, idx.Search(q, limit))
 }
 
-// ServeRecognize recognizes image uploaded via HTTP.
+// Request to recognize already available image.
+type recognizeRequest struct {
+	// SHA1 of image in the local file store.
+	ImageID string `json:"image_id"`
+	// URL to fetch image from.
+	URL string `json:"url"`
+}
+
+// ServeRecognize recognizes image uploaded via HTTP. Alternatively it
+// accepts JSON request with ID of already uploaded image or image URL.
 func ServeRecognize(w http.ResponseWriter, r *http.Request) {
 	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	ctype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ctype == "application/json" {
+		serveRecognizeJSON(w, r)
+		return
+	}
 	if err := r.ParseMultipartForm(0); err != nil {
-		serveError(w, r, kpopnet.ErrParseForm, 400)
+		serveError(w, r, kpopnet.ErrParseForm)
 		return
 	}
 	fhs := r.MultipartForm.File["files[]"]
 	if len(fhs) != 1 {
-		serve400(w, r, kpopnet.ErrParseFile)
+		serveError(w, r, kpopnet.ErrParseFile)
 		return
 	}
-	idolID, err := facerec.RequestRecognizeMultipart(fhs[0])
-	switch err {
-	case kpopnet.ErrParseFile:
-		serve400(w, r, err)
+	res, err := facerec.RequestRecognizeMultipart(r.Context(), fhs[0])
+	if err != nil {
+		serveError(w, r, err)
 		return
-	case kpopnet.ErrBadImage:
-		serve400(w, r, err)
+	}
+	serveJSON(w, r, res)
+}
+
+func serveRecognizeJSON(w http.ResponseWriter, r *http.Request) {
+	var req recognizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		serveError(w, r, kpopnet.ErrParseJSON)
 		return
-	case kpopnet.ErrNoIdol:
-		serve400(w, r, err)
+	}
+	imgData, err := req.load(r.Context())
+	if err != nil {
+		serveError(w, r, err)
 		return
-	case nil:
-		// Do nothing.
-	default:
-		serve500(w, r, err)
+	}
+	res, err := facerec.RequestRecognize(r.Context(), imgData)
+	if err != nil {
+		serveError(w, r, err)
 		return
 	}
-	if idolID == nil {
-		serve400(w, r, kpopnet.ErrNoSingleFace)
+	serveJSON(w, r, res)
+}
+
+// Load image referenced by the request.
+func (req recognizeRequest) load(ctx context.Context) (imgData []byte, err error) {
+	switch {
+	case req.ImageID != "" && req.URL == "":
+		return loadImage(ctx, req.ImageID)
+	case req.URL != "" && req.ImageID == "":
+		return fetchImage(ctx, req.URL)
+	default:
+		err = kpopnet.ErrParseJSON
 		return
 	}
-	result := map[string]string{"id": *idolID}
-	serveJSON(w, r, result)
 }
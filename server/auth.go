@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/db"
+)
+
+// Scopes of requests made without API key when key isn't required.
+var anonymousScopes = []kpopnet.Scope{kpopnet.ScopeRead, kpopnet.ScopeRecognize}
+
+// Look up API key by secret. Replaced in tests.
+var getAPIKey = db.GetAPIKey
+
+type apiKeyCtxKey struct{}
+
+// Get API key of the request, nil means anonymous client.
+func requestAPIKey(ctx context.Context) *kpopnet.APIKey {
+	key, _ := ctx.Value(apiKeyCtxKey{}).(*kpopnet.APIKey)
+	return key
+}
+
+// Key is accepted either as bearer token or in X-API-Key header.
+func getAPIKeySecret(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		const prefix = "Bearer "
+		if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+			return auth[len(prefix):]
+		}
+		return ""
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+// Client IP is taken from configured proxy header if set. Only the
+// rightmost address is used since it's added by our proxy, the rest is
+// sent by client and can be spoofed.
+func clientIP(r *http.Request) string {
+	if header := getOptions().IPHeader; header != "" {
+		if values := r.Header.Values(header); len(values) > 0 {
+			addrs := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(addrs[len(addrs)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Check that client is allowed to make request requiring the given
+// scope and pass its API key to the handler via context.
+func authorize(scope kpopnet.Scope, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var key *kpopnet.APIKey
+		if secret := getAPIKeySecret(r); secret != "" {
+			var err error
+			if key, err = getAPIKey(r.Context(), secret); err != nil {
+				serveError(w, r, err)
+				return
+			}
+			if key == nil {
+				serveError(w, r, kpopnet.ErrUnauthorized)
+				return
+			}
+		} else if getOptions().RequireAPIKey || !hasAnonymousScope(scope) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			serveError(w, r, kpopnet.ErrUnauthorized)
+			return
+		}
+		if key != nil && !key.HasScope(scope) {
+			serveError(w, r, kpopnet.ErrForbidden.WithDetails(map[string]interface{}{
+				"scope": scope,
+			}))
+			return
+		}
+		if key != nil {
+			r = r.WithContext(context.WithValue(r.Context(), apiKeyCtxKey{}, key))
+		}
+		h(w, r)
+	}
+}
+
+func hasAnonymousScope(scope kpopnet.Scope) bool {
+	for _, s := range anonymousScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+// Serve API keys from the map instead of DB. Returns restore function.
+func fakeAPIKeys(keys map[string]*kpopnet.APIKey) func() {
+	orig := getAPIKey
+	getAPIKey = func(ctx context.Context, secret string) (*kpopnet.APIKey, error) {
+		return keys[secret], nil
+	}
+	return func() { getAPIKey = orig }
+}
+
+var testKeys = map[string]*kpopnet.APIKey{
+	"reader": {ID: 1, Name: "reader", Scopes: []kpopnet.Scope{kpopnet.ScopeRead}},
+	"admin":  {ID: 2, Name: "admin", Scopes: []kpopnet.Scope{kpopnet.ScopeAdmin}},
+}
+
+func TestAuthorize(t *testing.T) {
+	defer fakeAPIKeys(testKeys)()
+	defer func(opts Options) { options = opts }(options)
+	tests := []struct {
+		require bool
+		header  string
+		value   string
+		scope   kpopnet.Scope
+		status  int
+	}{
+		{false, "", "", kpopnet.ScopeRead, 200},
+		{false, "", "", kpopnet.ScopeRecognize, 200},
+		{false, "", "", kpopnet.ScopeAdmin, 401},
+		{true, "", "", kpopnet.ScopeRead, 401},
+		{true, "X-API-Key", "reader", kpopnet.ScopeRead, 200},
+		{true, "Authorization", "Bearer reader", kpopnet.ScopeRead, 200},
+		{true, "Authorization", "Basic reader", kpopnet.ScopeRead, 401},
+		{false, "X-API-Key", "unknown", kpopnet.ScopeRead, 401},
+		{false, "X-API-Key", "reader", kpopnet.ScopeRecognize, 403},
+		{false, "X-API-Key", "reader", kpopnet.ScopeAdmin, 403},
+		{false, "X-API-Key", "admin", kpopnet.ScopeRecognize, 200},
+	}
+	for _, test := range tests {
+		options.RequireAPIKey = test.require
+		var gotKey *kpopnet.APIKey
+		h := authorize(test.scope, func(w http.ResponseWriter, r *http.Request) {
+			gotKey = requestAPIKey(r.Context())
+		})
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/", nil)
+		if test.header != "" {
+			req.Header.Set(test.header, test.value)
+		}
+		h(rec, req)
+		if rec.Code != test.status {
+			t.Errorf("%+v: unexpected status %d", test, rec.Code)
+		}
+		if rec.Code == 200 && test.header != "" && gotKey == nil {
+			t.Errorf("%+v: key isn't passed to handler", test)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1")
+	if ip := clientIP(req); ip != "192.0.2.1" {
+		t.Errorf("expected remote address but got %q", ip)
+	}
+	options.IPHeader = "X-Forwarded-For"
+	if ip := clientIP(req); ip != "10.0.0.1" {
+		t.Errorf("expected address added by proxy but got %q", ip)
+	}
+
+	// Client can't escape its bucket by sending own header, proxy
+	// appends the real address.
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 198.51.100.7")
+	req.Header.Add("X-Forwarded-For", "2.2.2.2")
+	if ip := clientIP(req); ip != "2.2.2.2" {
+		t.Errorf("spoofed address used: %q", ip)
+	}
+
+	options.IPHeader = "X-Real-IP"
+	req.Header.Set("X-Real-IP", "203.0.113.5")
+	if ip := clientIP(req); ip != "203.0.113.5" {
+		t.Errorf("expected address from header but got %q", ip)
+	}
+}
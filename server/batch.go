@@ -0,0 +1,233 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/facerec"
+)
+
+const (
+	maxBatchFiles    = 200
+	maxBatchBodySize = int64(64 * 1024 * 1024)
+	// Keep in memory only that many files waiting for recognition.
+	maxBatchPending = 4
+	// Multipart files above this are stored on disk.
+	maxBatchMemory = int64(16 * 1024 * 1024)
+)
+
+// File of the batch which can be read on demand.
+type batchFile struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// Result of single file recognition. Index is a position of the file in
+// the request.
+type batchItem struct {
+	Index  int             `json:"index"`
+	Name   string          `json:"name,omitempty"`
+	Result *facerec.Result `json:"result,omitempty"`
+	Error  *kpopnet.Error  `json:"error,omitempty"`
+}
+
+// ServeRecognizeBatch recognizes multiple images uploaded via HTTP as
+// files[] parts or as a single zip/tar archive. Results are streamed as
+// newline-delimited JSON in order of completion.
+func ServeRecognizeBatch(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchBodySize)
+	// HTTP/1 server doesn't allow to read request while writing response
+	// so whole batch is received first.
+	files, cleanup, err := readBatch(r)
+	defer cleanup()
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	if len(files) == 0 {
+		serveError(w, r, kpopnet.ErrParseFile)
+		return
+	}
+	if len(files) > maxBatchFiles {
+		serveError(w, r, kpopnet.ErrTooManyFiles)
+		return
+	}
+
+	results := make(chan batchItem)
+	go recognizeBatch(r, files, results)
+
+	setAPIHeaders(w)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for item := range results {
+		// Continue draining results even if client has gone away.
+		if err := enc.Encode(item); err == nil && flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+// Recognize all batch files and close results channel when done.
+func recognizeBatch(r *http.Request, files []batchFile, results chan<- batchItem) {
+	var wg sync.WaitGroup
+	pending := make(chan struct{}, maxBatchPending)
+	for i, f := range files {
+		select {
+		case pending <- struct{}{}:
+		case <-r.Context().Done():
+			// Client has gone away, no need to process the rest.
+			wg.Wait()
+			close(results)
+			return
+		}
+		wg.Add(1)
+		go func(i int, f batchFile) {
+			defer wg.Done()
+			item := batchItem{Index: i, Name: f.name}
+			var err error
+			// Every file is charged separately so batch can't be used
+			// to bypass rate limit.
+			if wait := checkRate(r); wait > 0 {
+				err = rateLimitError(wait)
+			}
+			var data []byte
+			if err == nil {
+				data, err = readBatchFile(f)
+			}
+			if err == nil {
+				item.Result, err = facerec.RequestRecognize(r.Context(), data)
+			}
+			<-pending
+			if err != nil {
+				item.Error = toAPIError(r.Context(), err)
+			}
+			results <- item
+		}(i, f)
+	}
+	wg.Wait()
+	close(results)
+}
+
+func readBatchFile(f batchFile) (data []byte, err error) {
+	rc, err := f.open()
+	if err != nil {
+		if _, ok := err.(*kpopnet.Error); !ok {
+			err = kpopnet.ErrParseFile
+		}
+		return
+	}
+	defer rc.Close()
+	data, err = readLimited(rc)
+	if err != nil && err != kpopnet.ErrTooLarge {
+		err = kpopnet.ErrParseFile
+	}
+	return
+}
+
+// Read list of files from the request. Cleanup function must be called
+// even on error.
+func readBatch(r *http.Request) (files []batchFile, cleanup func(), err error) {
+	cleanup = func() {}
+	ctype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch ctype {
+	case "multipart/form-data":
+		if err = r.ParseMultipartForm(maxBatchMemory); err != nil {
+			err = bodyError(err, kpopnet.ErrParseForm)
+			return
+		}
+		cleanup = func() { r.MultipartForm.RemoveAll() }
+		for _, fh := range r.MultipartForm.File["files[]"] {
+			fh := fh
+			files = append(files, batchFile{fh.Filename, func() (io.ReadCloser, error) {
+				return fh.Open()
+			}})
+		}
+	case "application/zip", "application/x-zip-compressed":
+		var tmp *os.File
+		if tmp, err = ioutil.TempFile("", "kpopnet-batch-*.zip"); err != nil {
+			return
+		}
+		cleanup = func() {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+		var size int64
+		if size, err = io.Copy(tmp, r.Body); err != nil {
+			err = bodyError(err, kpopnet.ErrParseFile)
+			return
+		}
+		files, err = readZip(tmp, size)
+	case "application/x-tar":
+		files, err = readTar(r.Body)
+	default:
+		err = kpopnet.ErrParseForm
+	}
+	return
+}
+
+// Skip directories and service files like __MACOSX/._img.jpg.
+func isBatchFileName(name string) bool {
+	return !strings.HasSuffix(name, "/") &&
+		!strings.HasPrefix(path.Base(name), ".") &&
+		!strings.HasPrefix(name, "__MACOSX/")
+}
+
+func readZip(ra io.ReaderAt, size int64) (files []batchFile, err error) {
+	zr, err := zip.NewReader(ra, size)
+	if err != nil {
+		err = kpopnet.ErrParseFile
+		return
+	}
+	for _, zf := range zr.File {
+		if !isBatchFileName(zf.Name) || zf.FileInfo().IsDir() {
+			continue
+		}
+		files = append(files, batchFile{zf.Name, zf.Open})
+	}
+	return
+}
+
+// Tar can be only read sequentially so files are stored in memory, total
+// size is limited by the request size anyway.
+func readTar(r io.Reader) (files []batchFile, err error) {
+	tr := tar.NewReader(r)
+	for {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			err = nil
+			return
+		}
+		if err != nil {
+			err = bodyError(err, kpopnet.ErrParseFile)
+			return
+		}
+		if !hdr.FileInfo().Mode().IsRegular() || !isBatchFileName(hdr.Name) {
+			continue
+		}
+		data, readErr := readLimited(tr)
+		if readErr != nil && readErr != kpopnet.ErrTooLarge {
+			err = bodyError(readErr, kpopnet.ErrParseFile)
+			return
+		}
+		// Rest of too large file is skipped by the next call.
+		files = append(files, batchFile{hdr.Name, func() (io.ReadCloser, error) {
+			if readErr != nil {
+				return nil, readErr
+			}
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		}})
+	}
+}
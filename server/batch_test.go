@@ -0,0 +1,120 @@
+package server
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+var batchTestFiles = map[string]string{
+	"a.jpg":            "first",
+	"dir/b.jpg":        "second",
+	"__MACOSX/._a.jpg": "junk",
+	".hidden.jpg":      "junk",
+}
+
+func checkBatchFiles(t *testing.T, files []batchFile) {
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files but got %d", len(files))
+	}
+	for _, f := range files {
+		data, err := readBatchFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != batchTestFiles[f.name] {
+			t.Errorf("%s: bad content %q", f.name, data)
+		}
+	}
+}
+
+func TestReadBatchTar(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range batchTestFiles {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))})
+		tw.Write([]byte(content))
+	}
+	tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755})
+	tw.Close()
+
+	r := httptest.NewRequest("POST", "/api/recognize/batch", &buf)
+	r.Header.Set("Content-Type", "application/x-tar")
+	files, cleanup, err := readBatch(r)
+	defer cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBatchFiles(t, files)
+}
+
+func TestReadBatchZip(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range batchTestFiles {
+		fw, _ := zw.Create(name)
+		fw.Write([]byte(content))
+	}
+	zw.Create("dir/")
+	zw.Close()
+
+	r := httptest.NewRequest("POST", "/api/recognize/batch", &buf)
+	r.Header.Set("Content-Type", "application/zip")
+	files, cleanup, err := readBatch(r)
+	defer cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBatchFiles(t, files)
+}
+
+func TestReadBatchErrors(t *testing.T) {
+	tests := map[string]error{
+		"text/plain":      kpopnet.ErrParseForm,
+		"application/zip": kpopnet.ErrParseFile,
+	}
+	for ctype, expected := range tests {
+		r := httptest.NewRequest("POST", "/api/recognize/batch", ioutil.NopCloser(bytes.NewReader([]byte("garbage"))))
+		r.Header.Set("Content-Type", ctype)
+		_, cleanup, err := readBatch(r)
+		cleanup()
+		if err != expected {
+			t.Errorf("%s: expected %v but got %v", ctype, expected, err)
+		}
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestReadBatchBodyErrors(t *testing.T) {
+	ctypes := []string{"multipart/form-data; boundary=x", "application/zip", "application/x-tar"}
+	for _, ctype := range ctypes {
+		r := httptest.NewRequest("POST", "/api/recognize/batch", nil)
+		r.Body = http.MaxBytesReader(httptest.NewRecorder(), ioutil.NopCloser(bytes.NewReader(make([]byte, 1024))), 10)
+		r.Header.Set("Content-Type", ctype)
+		_, cleanup, err := readBatch(r)
+		cleanup()
+		if err != kpopnet.ErrTooLarge {
+			t.Errorf("%s: expected too large but got %v", ctype, err)
+		}
+
+		// Other read errors, e.g. client disconnect, aren't about size.
+		r.Body = ioutil.NopCloser(failingReader{})
+		_, cleanup, err = readBatch(r)
+		cleanup()
+		if err == kpopnet.ErrTooLarge || err == nil {
+			t.Errorf("%s: unexpected error %v", ctype, err)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Code generated by go-bindata. DO NOT EDIT.
+// sources:
+// openapi.json (27.643kB)
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes  []byte
+	info   os.FileInfo
+	digest [sha256.Size]byte
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _openapiJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5d\x5f\x6f\xdc\x38\x92\x7f\xf7\xa7\x20\x74\x07\xdc\x8b\xdc\x76\x92\x99\x05\x92\xb7\x4c\x32\x83\xf1\x6c\x32\x63\x38\xbb\xb7\x07\x04\x46\x83\x2d\x55\xb7\x38\x96\xc8\x1e\x92\x8a\xdd\x09\xfa\xbb\x1f\x8a\x22\x25\xea\x6f\x4b\xea\xb6\x9d\xc5\xdd\xee\x00\x63\xb5\xc8\x62\xfd\xf9\x55\x91\x2c\xb2\x34\xdf\xce\x08\x09\xc4\x16\x38\xdd\xb2\xe0\x0d\x09\x5e\x2d\x2e\x17\xaf\x82\x10\x7f\x65\x7c\x2d\x82\x37\x04\x5b\x10\x12\x68\xa6\x53\xc0\x16\x77\x5b\xb1\xe5\xa0\x4d\x1b\x42\x82\x18\x54\x24\xd9\x56\x33\xc1\xf1\xed\xdf\xcf\xb7\x62\x4b\xb6\x52\xac\x59\x0a\x8a\x50\x1e\x93\x35\x8d\x80\x48\x88\xc4\x86\x33\x6c\x46\xde\x5e\x5f\x2d\xc8\x0d\xfc\x95\x83\xd2\x8a\xdc\x33\x9d\x88\x5c\xe3\xaf\xe4\x0e\x76\x84\x4a\x20\x34\x4d\xc5\x3d\xc4\x44\x0b\x22\x81\xc6\x75\x7a\x96\xd4\x57\x30\x94\x15\xc9\x79\x0a\x4a\x11\x05\xf2\x0b\x48\x22\xe1\xaf\x9c\x49\x50\x48\x0b\x47\xa9\x86\x95\x6e\x44\x1c\x41\x52\x0d\x24\x65\x19\xd3\x10\x93\x2d\xc8\x72\x78\x21\xcd\x63\x94\x32\xe0\x9a\x5c\x5d\x2f\x9c\xa0\x5f\x40\x2a\x2b\xe4\xe5\xe2\x72\x71\x19\x9c\x11\xb2\xc7\x77\x41\x31\xb2\x0a\xde\x90\xcf\xdf\x82\x5c\xa6\xa8\x87\x8b\x60\x7f\x6b\x5f\x46\xb9\x64\x7a\x67\xde\xee\x43\xf2\x2d\x58\x01\x95\x20\xf1\xf9\xd6\x3c\xd3\x2d\xfb\x3b\xec\x8a\xe7\xa2\xcf\x96\xea\x44\x55\xaa\xbf\xa0\x5b\x76\x61\x6d\xb4\xf8\x53\x19\x1e\x8a\x57\x84\x04\x1b\xd0\xde\x63\x61\x4c\x49\x51\xcf\x57\x31\x32\xb2\x01\xfd\xc7\x16\xf8\xdb\xeb\x2b\x2b\x08\xfe\x13\xa8\x3c\xcb\xa8\xc4\x41\x83\x7f\x24\x4c\x91\x58\x44\x79\x06\xdc\x59\x95\x90\x16\xe7\xb7\xde\x1b\x09\x6a\x2b\xb8\x82\x8a\x47\xfb\xe2\xe5\xe5\x65\xe3\xa7\x36\x42\x2c\x37\xe5\x90\x4e\xc1\xee\x7f\x41\x24\xb8\x46\x56\xde\x18\xdd\x6c\x53\x16\x19\x71\x2e\x9c\xe4\x81\x8a\x12\xc8\xa8\xf9\x53\xef\xb6\x06\x94\x62\xf5\x27\x44\x3a\xd8\xef\xf7\x1e\xad\xfd\x59\xf3\xaf\xe2\xdf\x7b\x6b\x51\xa3\x57\x07\xad\x89\x3a\xbd\x76\xdd\xba\x95\xfa\x36\x4d\x2b\xd0\x0a\x49\xd6\x2c\xd5\x20\x11\x6a\x74\x03\x44\xac\x89\x4e\x20\xf3\xfb\x36\x94\xf4\x2f\xeb\x14\xa6\xf9\x96\x4a\x9a\x81\x06\xa9\x08\xf5\xe9\x1a\x1c\x83\xce\x25\x87\x78\x41\xb0\x0b\x51\x8c\x47\x40\x04\x4f\x77\x24\x4a\x28\xdf\xa0\x0b\xae\x35\x48\x1c\x8f\x6c\xd8\x17\x40\x37\xf8\xc2\x10\xc8\x8d\xee\x6f\xf9\x0e\xf9\x12\xeb\xb5\x02\x1d\x16\xae\x11\x92\x15\xe5\x71\x48\xe8\x06\x78\xb4\x0b\x49\x0c\xab\x5c\x2f\x77\x40\x65\x48\x12\xaa\x96\x5b\xa4\x05\xf7\x21\x51\x42\xea\xc2\xd7\x19\xa4\xb1\x22\x19\xbd\x03\x45\x1c\x4c\x08\x35\x62\xfb\x86\x0e\x2a\x99\x10\xf8\xe5\xef\x84\x7c\x0b\x38\xcd\x8c\x4d\x8d\x2c\x41\x88\x81\x08\x1f\xff\xca\x41\xee\xf0\xb1\xc3\xfc\x8c\x6b\xd8\x80\xc4\xb7\x6b\x21\x33\xaa\xed\x8f\x7f\xfb\x01\x7f\xca\x18\x67\x59\x9e\x05\x6f\xc8\xe5\x3e\x6c\xa9\xfa\xc6\xa8\xa0\x5b\x67\x4c\x95\xea\x5a\x04\xfb\xb0\x93\xcd\x42\x63\x93\xf9\xf4\x99\xea\xa1\x6c\x6c\x70\x14\xe1\xb6\xb4\xd7\x08\x28\xc5\xbe\x42\x48\x2e\x49\x06\x94\x2b\xc2\x45\x61\xed\x5e\x09\x11\x04\xe3\xd8\x50\x5a\x32\xbe\x09\x3a\xb4\xfc\x07\xaa\x97\xc5\x22\x55\x16\xfd\x06\x5a\x26\xf8\x17\x7a\xbe\x7a\xdf\x3b\x7e\x01\xbf\x93\x70\x80\x83\x96\x1c\x14\x74\x0d\x6e\x75\x02\x4c\x16\xfc\xf5\xb2\x51\xa1\xff\x18\x9b\xe0\x13\x7d\xb0\x4f\xaf\x5f\xbf\x7e\xdd\x67\x7d\xcf\xc1\xc6\x8d\xb7\x12\x22\x05\xca\x83\x3e\x82\xe8\xa4\x93\x94\x18\x92\x00\xb8\xe1\xf3\x73\xa1\x84\x90\x04\xe7\xee\x8f\x15\x93\x3a\x59\xc6\x54\x17\x3f\xd7\x1f\x0b\x55\x95\x2f\xbd\xc7\xdb\x3e\xee\x8a\xc8\x71\xac\x91\xdf\x89\x2c\xa3\xe7\x0a\x30\xbc\x68\x28\xe3\x91\x58\x1b\xb8\x15\xeb\x07\x63\xe5\x62\x69\x81\x9e\x1f\x12\x16\x13\x86\xa1\xf5\x9e\xee\x14\x61\x3c\x4a\xf3\x18\xe2\x16\x0c\xfe\x53\xc2\x1a\x87\xf8\x8f\x8b\x48\x64\x5b\xc1\x81\x6b\x75\x51\x85\xb1\x8b\xab\xf5\xef\x82\xc3\x47\xaa\xa3\x24\xa8\x66\x9e\x93\x4d\x9b\x6e\xc2\x39\x6a\xba\x14\x1c\xfe\x58\x37\x02\xee\x80\x74\x45\x4f\x75\x51\x4e\x76\xfb\x70\x5e\x47\x8c\x3a\x9e\x52\xf0\x9f\xdb\xc6\x5c\xed\x53\x0e\x5e\x5d\xfe\x10\xbc\xe9\x23\x5e\xaa\xf1\xe2\x77\xa1\x3f\x8a\x98\xad\x19\xc4\x75\x6b\x05\x31\xac\x69\x9e\xea\x31\x44\x7e\x96\x52\x48\x8f\xb9\x81\xb5\x82\x41\xce\xc5\x37\x16\xef\x27\xae\x16\xae\x62\x91\xf6\xac\x14\x3e\x31\xbe\x49\x81\xb0\x46\x8b\xfa\xfc\x38\x02\x7d\xef\xed\x82\xd3\xf6\x2f\xe5\x9b\x83\x35\x64\xf7\x28\x9c\x0d\x82\x02\xa9\x37\x57\x6a\x4f\x6a\xbc\x0b\xc5\x32\x96\x52\x39\xd1\x88\x9f\x8a\x5e\xc8\x7e\xdf\xb2\xcf\xbc\x23\xf7\x09\x8b\x12\x92\x0a\x71\x47\x52\x76\x07\xde\xda\x4b\x70\x08\x49\x94\x0a\x05\x4a\x93\x35\x93\x4a\xf7\xdb\xbc\xfc\x9d\x90\x71\xe6\xef\x8e\xab\xf3\x16\x11\x95\x09\x5e\xd4\x67\xac\x17\x97\x97\xfb\x47\x88\x6e\x56\xb7\x6e\x06\x3e\x02\x7a\x4e\x18\x2a\x25\x35\xf3\x07\xd3\x90\xa9\xc3\xa8\xb4\x1c\x20\x30\x9f\x1a\x99\x66\x6a\x9a\x13\x56\x7e\x32\x0b\xb3\x4e\x24\xda\xb0\xb2\x6a\xb4\xa8\x43\x6c\x1c\xae\x4e\x66\x64\x64\xf7\xf1\xc2\x0a\x52\x0f\x9e\xd3\x78\x45\x84\x99\x61\xc2\xa1\x88\x82\x1b\xc9\x0c\xb2\x15\xc8\x72\xd9\xfa\x5d\xd9\xf4\xea\xd9\x1c\xd6\x4d\x23\x4f\x6d\x70\x05\x54\x46\xc9\x14\x33\xdb\x1e\xdd\x06\x46\x31\x8a\x75\x29\xda\x15\xb7\xce\x3a\x4a\x18\xdf\x18\x53\x9b\x45\x70\x48\x56\x53\xa7\x0b\x17\xfc\xff\xea\x08\xfc\x36\x41\x86\x31\x44\xcb\x1c\x86\x57\xd8\xfb\xb0\x93\xee\xb1\x93\xca\xcb\xa7\x99\x54\x8c\xde\x31\x0d\x91\xa7\xfa\x99\x66\x15\xc3\xc2\x8d\xe1\xe0\x39\xc0\x5a\xe6\x4a\x3d\x65\x05\x5b\xa1\x86\x01\x5b\xf5\xea\xc6\xac\xcd\xaa\x7e\x2d\x96\xad\x44\x70\x03\x56\x96\xd1\x4d\xad\x87\x4d\xb8\xfe\x24\xe2\xdd\x90\x58\xae\x11\x03\x75\x71\x65\x68\xec\x4f\x85\x80\x92\xd1\x22\x0b\x7d\x14\x04\x06\xed\x6c\x07\xc2\xce\x95\xb1\xbd\xb1\x3c\x89\x08\x09\x7e\x78\xf9\x7a\x8c\x9d\x6f\xa8\x86\x0f\xe8\x69\x4f\xb4\xbd\x29\xcd\x7e\xb1\x32\xbb\xd8\x59\x90\xf9\xc9\x74\x3d\x84\x9b\x2c\x4f\x35\xdb\xa6\x16\x34\xb5\xc9\xaf\x65\x42\x54\x67\x91\xec\x54\x5a\x02\xcd\x20\x26\x8c\x13\x21\x63\x90\x98\xc9\x41\x6b\xa4\x80\x9c\x2c\xc8\xcf\x5f\x40\xee\x30\xd9\x0a\xb8\xa3\x8f\x12\x2a\x37\x10\x13\xba\xa1\x8c\x2b\xed\xe5\xfc\x89\xcb\x10\xa4\xbb\x90\xc0\x43\x04\x10\x63\xc8\xc5\x8e\x8a\x6c\xa0\x68\xba\x74\xc7\x03\x80\xfe\xe6\x63\xa7\x89\xec\xf2\x05\x69\x87\xd7\xb3\x6e\xb8\x79\x3f\x13\x12\x14\xea\xa0\x52\x5f\x60\xfa\xf2\x3c\xa6\x9a\x4e\x82\xdf\x2f\xc8\xf8\x2f\x42\x66\x8d\x88\x4d\x48\x0d\xd6\x5f\xd9\xb6\x2f\xb0\xd9\x80\x5f\x4b\xa0\xae\x18\xc7\xd5\xe4\x20\xc9\x87\x73\x4d\xe5\x2c\xa2\x1e\x4d\x0f\x9d\xa7\xf2\xfc\xdf\xe1\x3e\x65\x1c\xce\x63\x70\x56\x2c\xa6\x01\x44\x0c\x94\x28\x19\x1d\x10\x1e\xce\x79\x3c\x39\x28\x18\x57\xb8\xd2\x90\x3d\xc3\xb2\xf4\x0b\x48\xb6\xde\x4d\x72\x61\xdb\xa5\xdb\x75\xdf\x25\x10\xdd\x91\xfb\x04\x74\x82\xa7\x09\xf7\xc2\x7a\x2e\x51\x89\xb8\x37\xe1\x5f\xd1\x0c\xf0\xe8\x4c\x09\x74\xce\xe2\xb5\x79\xab\xcc\xeb\x66\x82\xe3\xe9\x5d\xa8\xd6\x9e\x90\xe6\xd9\x91\x3f\x0c\xfe\x3f\xd8\x4a\xdc\x71\x69\xd6\x02\xa0\x7d\x6f\xa2\xc5\xe7\xdb\x43\xeb\x83\x31\xce\x80\xab\x21\xc6\xaf\x6c\x9f\x17\xf8\x48\x1f\xdc\xe3\xcb\x7d\xd8\x1e\x1c\xd5\xb9\x64\x71\xd7\x08\x15\x3e\x2c\x3a\x5a\x92\x79\x0a\xfe\x5c\x8a\x71\x5b\x6b\x35\xe8\xf4\x93\x3d\xe1\xbf\x0d\xb4\xec\x91\xef\xa3\xfb\xbe\x19\xcd\xf2\x6a\xdd\x7e\xb4\xa3\xcf\x13\xcd\xf6\xfe\x77\x9c\xf4\xff\x14\x2b\x35\x29\x4c\x44\x12\xa8\x86\xdf\xc4\xaa\x27\x52\x54\x93\x7c\x11\x02\x18\x27\x2b\x1a\xdd\x6d\xa4\xc8\x79\xfc\xff\x11\x60\x4e\x04\x78\xb1\x0f\xdb\x83\x47\x34\x4d\x57\x34\xba\x5b\x16\x37\x0e\x86\x87\xc9\x25\xf3\xe0\x60\xc1\xf0\xfc\x71\xe1\x37\xb1\x3a\x4d\x50\x78\x79\x30\x28\xfc\x26\x56\xb8\x26\x54\x9a\x4a\x0d\xed\x34\x54\x02\x34\x2e\x76\xd2\xdf\x82\x0f\xc2\xba\x73\x43\x94\xc3\xc7\x4f\xff\xbc\xf9\xe0\xb2\x34\x7f\x8a\xd5\xa2\xbd\x74\x3a\x59\xd8\x41\xff\xfb\x37\x8d\x36\x73\x32\x9d\xfd\xe1\xe6\x5d\x2e\x25\xde\xd4\x51\x1a\x57\xf7\x95\xf2\xfb\x33\x25\x3d\xf2\x3c\x56\x86\xec\x37\x04\xc2\x73\xc1\xe0\x71\xac\x48\xe3\x8c\xf1\x0b\xdc\x51\xab\x0b\x9a\xc7\x4c\x4f\xb1\xa6\xe9\xf0\x0b\xf6\xed\xb1\xe8\xa7\x5c\x6d\x59\xc4\x44\xae\x48\x24\xf8\x9a\x49\xdc\xf1\xad\x9b\x1d\x1a\x5a\xbe\x81\x75\xae\xc0\x5d\x28\x10\x62\x99\x51\xbe\x5b\x9a\x5e\xc5\xfe\x8d\x30\x03\x0d\xbc\x5c\x26\x81\x64\x42\x42\x93\x3a\xd1\x09\xe5\x24\xa3\x0f\x4b\x23\x9f\xed\x6c\x1a\x6d\x88\x30\x23\x2d\x7a\xef\x47\x1d\xbe\xd5\xd5\x89\xc5\xce\xec\x1a\x07\xb6\x49\x56\x22\x97\xea\x88\x14\xdb\x8f\xb5\x0c\xdb\x8f\xbd\x97\x4c\xe2\xbc\x88\x42\xb0\x8c\x99\xd2\x74\xf4\x95\x1b\x9e\x67\xab\xe6\x98\x97\x8b\x57\xb5\x51\x2f\x17\x7f\xeb\x1b\x16\x15\xac\x28\xee\xdc\x27\x0a\xe9\x81\xfd\x64\x2e\xfa\x16\x21\x49\x24\x6c\x85\xd4\x8f\xe7\xab\x66\x94\x1b\x33\xc8\x33\xfb\x6c\x94\xe6\xca\x22\x70\xb4\xdb\xda\x3e\x43\x8e\xfb\xce\x92\xc5\x29\x30\xe7\x0d\xef\x0a\x49\x8a\xd9\x98\xae\x44\xf6\x09\x5c\x39\xe7\xdf\xab\x33\xcf\xf2\xaa\x41\xaf\x61\x5f\x47\x12\x7b\x4c\x97\xb1\xb6\x7e\x74\xa7\xb1\xe3\x7c\x17\x6e\x93\xd2\x15\xa4\x93\xf6\x4a\xa6\xc7\xa0\xcb\x20\x20\x65\x66\x31\x4b\x15\x81\x07\xa6\x34\xe6\x22\x85\x24\x1c\xee\x5b\x69\x93\x21\x5f\x41\x22\x4b\xf4\x83\x94\x45\xda\xba\x4a\x6a\xc9\xe1\x3b\x72\xf5\x5e\xa1\xef\x20\x4d\x72\x2f\xf2\x34\x36\xb9\x4e\x85\xf9\x30\x9a\x5a\x1e\x6c\x32\xdf\x64\x73\xcc\x06\xee\x04\x6e\x72\xfc\x4e\xef\x38\x10\x7d\x40\x33\x54\x1b\x0d\xcf\xf0\xa7\x72\x07\x33\x42\xea\x82\xcf\xe3\xb9\x83\x95\xa4\x00\xf2\x13\xbb\x43\x02\x34\xd5\xc9\xd7\x29\x93\xc6\x06\xf4\xaf\xa6\x57\x0f\xfc\x3f\xe0\xad\x18\xbc\xbc\xbf\x95\x62\x05\xbd\x40\xdb\xdf\x9e\xca\x4e\xd7\x52\x44\x38\x9e\xb9\xef\xc7\xbe\x3c\xe2\x89\x90\x95\xbb\x61\xa2\x43\x3a\xc6\x6a\x87\xdd\x54\x15\xdf\x00\x8d\x19\x07\xd5\x17\x64\xca\xf7\x4f\xa6\xe6\x4f\xa6\x22\x02\xf7\xdb\x46\xa0\xc7\xd3\x72\x25\xfa\x90\x2f\xfc\x78\xf9\x6a\x02\xcb\x5c\x68\x53\x75\xb2\x23\x3b\xd0\xcf\xc0\xfa\x59\xf3\xaf\x06\x46\x32\xd0\x92\x45\x6a\x22\x48\x3e\xda\x5e\xdd\x10\xb9\x96\x22\xc3\xd4\x7e\xae\x48\xd6\xd1\xf0\x71\x50\x62\x59\xc2\xd3\x3c\x6f\x7c\x0d\x0f\x9a\x14\x99\xab\x41\xe5\x63\xbb\x8b\x6d\x4a\x59\x4b\xed\xcd\x3c\xcd\x38\xf5\x96\xe5\x3c\x95\xad\x4a\x09\x4a\x0d\x7c\xc2\x51\x6a\xd2\x56\x93\x60\x35\x70\xa2\xf5\xb6\x5c\x93\x99\xa5\x98\x6d\x54\xc2\xb2\x9a\x2a\xab\x5e\xf6\xa7\x72\x69\x57\xa4\xa4\x90\x8e\x5b\xfe\xfd\xcf\xf9\xdb\xeb\xab\x73\xec\x57\x87\x44\x6d\x19\x5a\x32\x76\xf5\x3e\x78\xe3\xad\x1d\x99\x77\x87\x1f\x0b\x8c\xe6\xdf\x05\x09\xfc\xab\xce\xfe\x10\x57\xeb\x73\x7c\x71\x5e\xbc\xe9\x10\x64\x88\x7a\x4d\xa2\x6a\xc5\x50\x4f\xbe\x06\xc5\x05\x81\xea\x87\xa1\x25\x84\x0f\x97\xb3\x49\x89\xe2\x79\xa7\xad\xc7\xc6\x03\x9b\x37\xef\xc8\x88\x36\xa0\x1a\x9e\xf5\x3a\x5f\xe0\xdf\x89\xc6\xe1\x1b\x3e\xf7\xae\xa8\x6b\xa3\x69\x11\xe1\x12\x8a\x3b\x25\x20\x34\xd2\x39\x4d\x89\xad\x71\xf3\xee\xbe\x07\xc5\xe2\xc0\xd7\x60\x93\xe4\xcf\xad\xe3\xf1\x93\xc5\x48\xbb\x32\x29\x35\x51\xb1\xe5\x27\x2e\x87\x98\xb3\xf2\x16\x67\xfc\x78\x73\x40\x2b\x57\x08\x68\x0e\xf9\x6b\x7c\xfb\x49\xe0\x1b\xd0\x72\x77\xfe\x76\xad\x41\x36\x59\x6e\xed\xaf\xda\x89\xe0\x4f\x10\x09\xbc\xcd\xa5\x05\xb9\xa7\xa6\xb4\x66\xff\x24\xfa\xa9\xe1\xc3\xb6\xf2\xf4\xd3\x65\xcd\xde\x43\x90\xfe\xe3\x8f\x00\x1c\x99\xa6\x1b\xb7\x35\xf1\x6b\x9e\x51\x7e\x8e\x68\xa3\xab\x14\x48\x06\x4a\x99\x4d\x85\xa7\x0f\xe3\xa9\x31\x8c\xa3\xf7\x91\xe2\xf5\x38\xa8\x28\x46\x22\x86\x90\xc0\x62\xb3\x20\x5c\x2c\x95\xb9\xf7\x6a\xb6\xfb\x78\x0a\xed\xdf\xe3\x68\x8e\x19\x83\xa6\x2c\xad\x1f\xd1\x38\x25\x90\x80\xc6\xb1\x29\x1b\xa5\xe9\xb5\xaf\x06\x8c\x30\x4e\xdb\x1e\x1e\xeb\x61\xe8\xb3\x55\x50\x68\x05\xbb\x3d\x6b\x34\x0f\xf0\xb2\xea\x2f\x45\xc5\xcb\x48\x63\x34\xd4\x80\x04\x08\x56\xe8\x2e\xc8\x3f\xf9\x1d\x17\xf7\xdc\xd5\xba\x60\x4e\xe4\x0e\xb6\x9a\x50\x5c\xe6\x2e\xc6\x19\xb4\xe7\x24\xb9\xea\x4b\xca\x59\xe8\x40\x2b\x9a\xea\x25\xb6\x54\x13\x4f\xc5\x1a\xa1\xf4\x4e\x48\xa0\x7c\x39\x72\x4c\x53\xc8\x35\xb2\xb1\x57\x8c\x74\xe8\xd4\xcc\x34\x6a\xf4\x66\x0a\xef\x69\xce\xee\x9f\xcb\x74\xba\x66\x4a\x02\x1e\xad\x21\x80\x9e\x35\x5a\x1b\xb8\xd5\x81\x46\xd3\xb4\xa8\x04\x3a\x10\x61\x3c\xa0\x9a\x2d\x7e\x0d\xe5\xc5\x92\xc2\xa8\xfd\x76\xdf\x06\x39\x5e\x6d\x3d\x0a\xe4\x48\xe0\x89\x41\x6e\x8c\xcb\xe2\x71\xb1\xe8\x5a\x32\x5c\x40\x9b\xda\xb2\x66\x74\x31\xb9\x93\xd1\x94\x3e\xfd\xfa\xf6\x05\xa6\x4d\x6d\xc1\x9f\xcb\xbc\xd4\x49\x3e\xa9\x03\x16\x05\x7d\xe3\x86\x9c\xe4\xac\x5e\xa5\xe0\x0c\xff\x39\xce\x7b\x13\x3c\x59\xd1\xb5\x9e\x2e\xe3\x5a\x6b\x77\x3f\xb2\xdd\x56\x28\xe3\x83\x47\xea\xfa\x79\x82\x02\x7a\xd7\xbc\xa0\xe0\x39\x76\x5f\x50\x70\x7e\x34\x14\x1f\x3e\x16\x75\x0d\x49\x71\x2b\x71\x4c\x7c\x18\xf2\xed\xfe\xfb\x50\x63\x1d\xbc\xde\x50\x8a\x14\x0e\xb7\x32\xf7\x09\x66\xe3\x11\xa6\xcd\x24\x65\xd7\xde\x75\x87\x53\x83\x67\x81\xdb\xc3\xf0\x58\xd3\x54\xb5\xf1\x51\x56\x7d\x1e\x6d\x1c\x57\x43\xdf\xb9\x7a\xae\xc9\x59\xd4\xec\xb7\x2d\x76\xd0\x3d\x0e\x4e\x62\x4d\x9f\x2b\x8b\x75\x8e\x2d\x40\xa9\x51\xb5\xa5\x3a\x09\xdb\x1e\x47\xdb\x73\x8d\xc6\x08\x31\xa4\xa0\x21\x5e\x8e\xd2\x4a\x03\x52\x3d\xa4\x46\xa9\xa2\x45\xaa\xa4\xd4\x0b\xc6\xd2\xec\x16\x8d\xc5\x49\xaf\x19\x2c\xac\xab\xea\x68\x8c\x5e\xb7\xb2\x02\xfd\x38\x6d\x4e\xe2\xf6\x8b\x1c\xee\xb3\x1a\x0b\xf2\x53\xa3\x54\x1c\xb7\x6b\x14\xaf\x9c\xe3\x37\x0c\xec\xe6\xb1\xaa\x2d\x67\x6b\xf7\x57\x99\x86\xc1\xc4\xa7\xaa\xe7\x0e\xbf\x73\xef\x70\xd1\xfc\x31\x7c\xa4\x9b\xf6\xff\x21\x4f\xa9\x91\xd2\x42\xd3\xb4\x83\x27\x67\xf0\xfa\xc0\x45\xeb\xf6\xb0\x65\xeb\xb2\xf1\x09\xbc\x30\xac\x73\x57\x3e\x16\x8d\x67\xfb\xa8\xab\xac\x3d\x7a\x1a\x19\xbb\x98\x46\xd0\xb5\xbf\x27\x52\x9d\xc4\x77\x2d\xeb\xca\x86\xbd\x7a\x2c\xd6\x35\x25\x91\xf9\xea\xf0\x4b\xc2\xe6\x85\xac\x9f\x19\x5e\x7f\xb0\xc5\x57\xd2\x6c\x3f\xa6\x85\x1c\x15\x09\xd9\xad\x87\x96\xff\x8f\xf3\xf1\x46\xc7\x95\xdd\x6f\x0e\x77\xc4\x38\x3b\x46\xf3\x05\xb7\xb3\x15\x5e\xa5\x6b\x8f\x46\xe0\x09\x2f\xfc\x8e\x90\xbb\x79\x03\xb7\x12\xa9\x95\x2b\x9e\x89\xa3\x07\x1a\xe9\x74\x47\x04\x37\x33\xa0\xdb\xb2\x9a\x6a\xd4\x5c\xa6\x58\xbd\x81\x97\x04\x56\x30\x01\x5a\xf3\xf6\xbd\x2e\x1f\x9d\x6f\x53\x41\x63\x88\xbb\x37\xc0\x63\x6f\x3a\xb7\xc7\xc1\x8b\xb9\x5a\x90\x35\xe8\x28\xb1\xf7\xd2\xd7\x52\x64\x0b\x1f\x7e\x2d\x2d\x5f\x0b\xd6\x38\x3c\x18\x50\x6d\x5d\x23\xc1\x43\x77\xb8\x0e\x49\xb0\xeb\x7e\xd3\x8b\x82\x07\x94\x6f\x37\x1f\xfe\x37\x10\xcd\x14\xa2\xfc\x9d\x90\x5e\x79\xfc\x36\x3d\x92\x55\xf4\x71\x67\xcd\x62\x9d\x8c\x68\xd7\xb1\x53\x2f\x1b\x96\xed\x0e\xe9\x2c\x74\xe3\x85\x25\xc1\xd9\x6a\xfc\x54\x2f\xa2\x9d\x82\x85\x21\x99\x87\x25\xed\x15\xf0\x64\x62\xb5\x0b\x57\x67\xc9\x38\x67\x9a\x2e\x83\x58\xb1\xce\x9e\x38\x63\xb7\xe9\xbd\xb7\xad\xf1\xd3\x4e\x78\xa6\xe5\xbe\xb3\x52\x5c\x33\x75\x57\xc3\x71\xa8\xe6\x38\x12\x22\x4d\xf1\xdc\xe0\xf0\x9c\x65\xbc\xa9\xde\x3b\xa5\x3c\xce\xa8\xbc\x53\x87\xa6\x85\x41\xc2\x45\xb0\x69\x2c\x16\x99\x3b\xed\x1c\x66\xca\x60\xd3\x8b\x64\x7d\xa8\x69\x2c\x61\x42\x5f\xf2\xd0\x17\x24\x74\x43\xcf\xc6\x55\x55\xfb\x38\x12\x4f\xdd\x4b\x1c\x57\xb4\x29\xdd\xcd\xcf\x29\xab\x1c\xc6\x63\xe8\x8e\x5b\x6d\xf0\x5c\xdb\x4c\x9e\x83\x09\xce\xbd\x78\x31\x01\xff\xb6\x5b\xbe\x26\x6a\xc6\xa5\x3b\x65\xe9\x55\x93\x2b\xc8\xc3\xee\x23\xb7\x31\x27\x82\x65\xcf\x7e\x2c\x18\xe5\xcc\xb6\x6f\xbd\xa2\xef\x28\x1b\x7b\xc5\xa4\x42\x9a\xb5\x88\x79\x2a\x37\xe0\x26\x30\x8c\x33\x38\xf6\x3b\xce\x0d\x5b\x2b\xab\x29\xb5\x99\xe3\xaa\x32\xfb\x4d\x52\xb0\xdf\x5e\xee\xd5\x6a\x0c\x47\xea\xba\x5f\x4b\x59\x79\x6f\xa3\xf5\xe5\xc0\xd1\xd1\xd7\x93\xc1\x64\xbd\x28\x5f\x0e\x35\x6f\xfb\x9b\xf9\x1a\xa3\x02\x8d\x1f\x8f\x40\xad\x91\x2f\x9e\x8c\x4d\x4f\x73\x85\x02\xe3\x5c\xf9\x77\x93\xd1\x47\x47\x46\xc2\xff\xa5\xec\x04\x80\x79\x9c\x6c\x4b\x65\xf5\x41\x80\xe6\x30\x3a\x91\xa0\x12\x91\xc6\x5d\x22\x8c\xb0\x5f\xe6\xae\xbd\x94\xaa\x08\x7d\xa2\xb3\x7d\xcd\xab\x92\xeb\x4e\xd7\x1f\xfe\x54\xd8\x00\xbc\x6b\x9d\xeb\xa8\x99\x5e\x63\xd8\xbb\xf2\xbe\xfe\xe3\xd3\x3f\xc8\x9a\x71\xa6\x12\x88\xb1\x3e\x8b\x68\xb1\xf0\x6e\x2e\x10\xd2\x86\x3d\x16\x37\xd5\x04\x9e\x83\xf6\x1e\x6f\xac\xfa\xe2\x45\x09\x4d\x75\xae\xba\xda\xf9\x9f\xc0\xdc\x02\x8f\xed\x6f\xb1\xe0\xe6\x0e\xfe\x9a\xb2\x14\xe2\xe0\xf6\x7b\x08\xf9\xb5\xae\x45\x91\x6e\xbc\xa4\xba\x47\xaa\xca\x6c\x78\xb4\x70\xae\x59\xd6\x3c\x9b\x70\xc6\x9a\x48\xa3\x24\xd1\xeb\x26\xc5\x2a\xc4\xea\x3c\xac\xf1\x3a\xdb\x45\xfc\xda\x9a\xa3\x21\x83\x97\x47\xba\x83\x8d\x27\x13\x86\x3d\xa6\x52\x7b\xef\xfb\xc0\x9c\xe3\xf5\x1a\xe2\x69\x98\xaf\x92\xb7\xe6\x0c\xe3\x85\xc2\xc1\x5c\xf1\xc1\x29\xaa\xd5\xb8\xac\x44\x5b\xce\xec\x66\xd9\x55\x07\xf4\x33\x4a\x94\x2a\x54\x34\xe0\xd5\x86\x98\xd3\x52\x48\xfc\x13\xb1\xb6\x34\x61\x27\xaf\xb7\x67\x7d\xf5\xce\x55\xb5\xdc\x21\x89\x4e\x6e\xf1\x43\x03\x8e\x52\x61\x7d\x2d\xf3\x72\x68\x2d\xe3\x83\x65\xf4\xd8\x25\x22\x26\x8e\x33\xb4\x76\x98\x6e\xf6\x32\xc3\xed\xfe\x2e\xc9\x0f\x58\x76\x0d\xf7\x65\x41\xe2\xb0\xb4\xa7\x33\xad\xe5\xb1\x5b\x85\x8f\x89\x83\x09\x3a\x75\x3c\x86\x24\xe8\x71\x90\xb3\x0e\x3a\x6d\xbb\x18\xa3\xf8\x11\x33\xac\x79\x53\x58\xb7\xc0\xed\x59\x83\x64\x50\x2f\x04\xf3\x95\xd9\x6f\x82\x7e\xf5\x5b\x96\xba\xf4\xe5\x49\x41\xca\xb2\xc7\x43\x5a\x3f\x1d\x26\x4e\x6a\xe6\xfa\xc8\x6e\x87\x34\xc7\xa3\x6b\x5a\xb1\x69\x8f\x15\x34\x4e\xa5\x9c\xbc\xbd\x93\x77\x9b\xed\xfd\xd0\xfa\x1d\x75\xa1\xf0\x9b\x42\xc5\x3e\x50\x27\xf6\x3f\x0f\x12\x15\x35\xff\xe9\x8e\x38\x34\x99\x02\x38\x3f\x9b\x7b\x10\xd7\xa5\x9e\x5d\xae\xc3\x3d\x58\xb9\xea\x18\xf7\x29\x05\x5c\x30\x05\x9d\xd2\x1c\x16\x06\x8b\x4b\x56\x90\x0a\xbe\xc1\x22\x3f\x2d\x08\xe5\x3b\x62\x41\x56\xcb\x46\x87\x67\xfd\x7c\x1b\x77\x29\x91\x19\x3a\x8e\xda\x4e\x53\x2b\x7c\xf3\xc1\xd6\x32\x59\xf5\xa6\x7b\x93\x6e\xc3\x00\x0a\x52\x96\x28\xba\xf3\x27\xbc\xa1\xe7\xf2\x26\x58\xb3\x28\x38\x2c\xc6\x7b\xe1\xe3\xcc\x6d\xf5\x4f\xa6\x8c\x8e\xb4\x13\x8e\xbc\xdc\xb1\x76\xd9\x7b\xd0\x64\xb5\xb8\xd9\x32\x90\xad\xe7\x1b\x69\xa1\x7e\x7d\xce\x3e\xb0\x1b\xad\x9f\x32\x7c\x8f\x35\x4b\xd9\xb9\x57\x3d\x6e\x6c\xe7\x7b\x29\x1c\xb1\x57\xb6\x65\x77\xb3\x34\x39\xb0\x0f\xdc\xf7\x72\x6f\xfb\xb4\x4d\x5b\xd5\x77\xcd\x62\xa6\xfc\xdd\x0c\x48\xe3\x5d\x8d\xad\x9e\x8c\xcd\xaa\x8b\xf7\x76\x50\x12\x77\xe8\xb6\x39\xa7\x5f\x28\x4b\xf1\x5a\x7e\x33\x13\x92\x89\x18\x52\xb5\x2c\xce\xe2\x46\x0c\xac\x25\x65\x1c\x6f\xb2\xd1\xf1\x7d\xee\xa9\xcc\x96\x55\xc7\xce\x1e\x6d\xd6\xff\xe5\xbe\x40\x87\x1d\x09\x76\xc4\x9c\xb0\x3d\x34\x14\x9c\x98\xfb\x78\xf6\x3f\x13\x55\x60\x0c\x8f\x03\x56\xe0\x0a\x11\xeb\x3c\x74\x2d\xf8\x1c\x98\xeb\x2d\x8f\xbc\x82\x81\xf6\x43\x61\x56\x41\xd8\xd4\x6e\xd8\xa5\xbe\xb0\xad\x9f\xb0\x62\xd7\x2e\x6f\xa7\xdf\xc9\xa8\x2a\xdd\xce\xf6\x67\xff\x3b\x00\xb1\x4c\x3e\xf3\xfb\x6b\x00\x00")
+
+func openapiJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_openapiJson,
+		"openapi.json",
+	)
+}
+
+func openapiJson() (*asset, error) {
+	bytes, err := openapiJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "openapi.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd3, 0x2e, 0x92, 0xe0, 0xd8, 0x55, 0xb0, 0x59, 0xc0, 0x40, 0x60, 0x2b, 0x0, 0x9c, 0x52, 0x4c, 0xca, 0x6a, 0xb6, 0x28, 0x18, 0x46, 0x3e, 0xe5, 0x7c, 0x16, 0x6b, 0xcd, 0xa6, 0xe5, 0xbe, 0x8f}}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// AssetString returns the asset contents as a string (instead of a []byte).
+func AssetString(name string) (string, error) {
+	data, err := Asset(name)
+	return string(data), err
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// MustAssetString is like AssetString but panics when Asset would return an
+// error. It simplifies safe initialization of global variables.
+func MustAssetString(name string) string {
+	return string(MustAsset(name))
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetDigest returns the digest of the file with the given name. It returns an
+// error if the asset could not be found or the digest could not be loaded.
+func AssetDigest(name string) ([sha256.Size]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s can't read by error: %v", name, err)
+		}
+		return a.digest, nil
+	}
+	return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s not found", name)
+}
+
+// Digests returns a map of all known files and their checksums.
+func Digests() (map[string][sha256.Size]byte, error) {
+	mp := make(map[string][sha256.Size]byte, len(_bindata))
+	for name := range _bindata {
+		a, err := _bindata[name]()
+		if err != nil {
+			return nil, err
+		}
+		mp[name] = a.digest
+	}
+	return mp, nil
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"openapi.json": openapiJson,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"},
+// AssetDir("data/img") would return []string{"a.png", "b.png"},
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error, and
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		canonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(canonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+
+var _bintree = &bintree{nil, map[string]*bintree{
+	"openapi.json": &bintree{openapiJson, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory.
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+}
+
+// RestoreAssets restores an asset under the given directory recursively.
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(canonicalName, "/")...)...)
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures cross-origin access to API.
+type CORSOptions struct {
+	// Allowed origins, "*" allows any origin. Empty disables CORS.
+	Origins []string
+	// Allowed methods, empty means GET and POST.
+	Methods []string
+	// Allowed request headers, empty means headers used by API clients.
+	Headers []string
+	// Allow requests with credentials. Can't be used with "*" since that
+	// would let any site make requests on behalf of the user.
+	Credentials bool
+	// How long preflight response can be cached in seconds, 0 leaves it
+	// to browser.
+	MaxAge int
+}
+
+var (
+	defaultCORSMethods = []string{"GET", "POST"}
+	defaultCORSHeaders = []string{"Authorization", "Content-Type", "X-API-Key", "X-Request-ID"}
+	// Response headers readable by scripts in addition to safelisted ones.
+	corsExposeHeaders = "ETag, Location, Retry-After, X-Request-ID"
+)
+
+func (o CORSOptions) methods() []string {
+	if len(o.Methods) == 0 {
+		return defaultCORSMethods
+	}
+	return o.Methods
+}
+
+func (o CORSOptions) headers() []string {
+	if len(o.Headers) == 0 {
+		return defaultCORSHeaders
+	}
+	return o.Headers
+}
+
+func (o CORSOptions) validate() error {
+	if o.Credentials && containsFold(o.Origins, "*") {
+		return errors.New("CORS credentials can't be allowed for any origin")
+	}
+	return nil
+}
+
+// Get value of Access-Control-Allow-Origin for the origin, empty means
+// it's not allowed.
+func (o CORSOptions) allowOrigin(origin string) string {
+	for _, allowed := range o.Origins {
+		if allowed == "*" {
+			return "*"
+		}
+		if strings.EqualFold(allowed, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Set CORS headers on API responses and answer preflight requests.
+func cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opts := getOptions().CORS
+		h := w.Header()
+		origin := r.Header.Get("Origin")
+		allowOrigin := ""
+		if origin != "" {
+			allowOrigin = opts.allowOrigin(origin)
+		}
+		if len(opts.Origins) > 0 && allowOrigin != "*" {
+			// Response depends on origin, caches should know.
+			h.Add("Vary", "Origin")
+		}
+		reqMethod := r.Header.Get("Access-Control-Request-Method")
+		if r.Method == "OPTIONS" && reqMethod != "" {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			if allowOrigin != "" && containsFold(opts.methods(), reqMethod) {
+				setAllowOrigin(h, allowOrigin, opts.Credentials)
+				h.Set("Access-Control-Allow-Methods", strings.Join(opts.methods(), ", "))
+				h.Set("Access-Control-Allow-Headers", strings.Join(opts.headers(), ", "))
+				if opts.MaxAge > 0 {
+					h.Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if allowOrigin != "" {
+			setAllowOrigin(h, allowOrigin, opts.Credentials)
+			h.Set("Access-Control-Expose-Headers", corsExposeHeaders)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func setAllowOrigin(h http.Header, origin string, credentials bool) {
+	h.Set("Access-Control-Allow-Origin", origin)
+	if credentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// Answer OPTIONS requests which aren't CORS preflight ones.
+func serveOptions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORS(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	router := createRouter()
+	tests := []struct {
+		cors        CORSOptions
+		method      string
+		origin      string
+		reqMethod   string
+		status      int
+		allowOrigin string
+		credentials string
+		maxAge      string
+	}{
+		{CORSOptions{}, "GET", "https://a.com", "", 400, "", "", ""},
+		{CORSOptions{Origins: []string{"*"}}, "GET", "https://a.com", "", 400, "*", "", ""},
+		{CORSOptions{Origins: []string{"https://a.com"}, Credentials: true}, "GET", "https://a.com", "", 400, "https://a.com", "true", ""},
+		{CORSOptions{Origins: []string{"https://a.com"}}, "GET", "https://b.com", "", 400, "", "", ""},
+		{CORSOptions{Origins: []string{"https://a.com"}, MaxAge: 600}, "OPTIONS", "https://a.com", "GET", 204, "https://a.com", "", "600"},
+		{CORSOptions{Origins: []string{"https://a.com"}}, "OPTIONS", "https://b.com", "GET", 204, "", "", ""},
+		{CORSOptions{Origins: []string{"*"}, Methods: []string{"GET"}}, "OPTIONS", "https://a.com", "POST", 204, "", "", ""},
+	}
+	for _, test := range tests {
+		options.CORS = test.cors
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(test.method, "/api/search", nil)
+		req.Header.Set("Origin", test.origin)
+		if test.reqMethod != "" {
+			req.Header.Set("Access-Control-Request-Method", test.reqMethod)
+		}
+		router.ServeHTTP(rec, req)
+		h := rec.Header()
+		if rec.Code != test.status ||
+			h.Get("Access-Control-Allow-Origin") != test.allowOrigin ||
+			h.Get("Access-Control-Allow-Credentials") != test.credentials ||
+			h.Get("Access-Control-Max-Age") != test.maxAge {
+			t.Errorf("%+v: unexpected response %d %v", test, rec.Code, h)
+		}
+	}
+}
+
+func TestCORSWildcardCredentials(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.CORS = CORSOptions{Origins: []string{"https://a.com"}}
+	opts := Options{CORS: CORSOptions{Origins: []string{"*"}, Credentials: true}}
+	if err := Reload(context.Background(), opts); err == nil {
+		t.Error("wildcard origin with credentials accepted")
+	}
+	if options.CORS.Origins[0] != "https://a.com" {
+		t.Errorf("invalid options applied: %+v", options.CORS)
+	}
+	if err := Start(opts); err == nil {
+		t.Error("wildcard origin with credentials accepted on start")
+	}
+}
+
+func TestCORSPreflightHeaders(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.CORS = CORSOptions{Origins: []string{"*"}}
+	options.RequireAPIKey = true
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("OPTIONS", "/api/recognize", nil)
+	req.Header.Set("Origin", "https://a.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "x-api-key")
+	createRouter().ServeHTTP(rec, req)
+	// Preflight doesn't carry credentials so it shouldn't be authorized.
+	if rec.Code != 204 {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	if methods := rec.Header().Get("Access-Control-Allow-Methods"); methods != "GET, POST" {
+		t.Errorf("unexpected methods %q", methods)
+	}
+	if headers := rec.Header().Get("Access-Control-Allow-Headers"); headers != "Authorization, Content-Type, X-API-Key, X-Request-ID" {
+		t.Errorf("unexpected headers %q", headers)
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Supported content codings.
+const (
+	encBrotli   = "br"
+	encGzip     = "gzip"
+	encIdentity = "identity"
+)
+
+// Compressed codings in order of preference.
+var compressedEncodings = []string{encBrotli, encGzip}
+
+// Single representation of the encoded response.
+type encodedVariant struct {
+	data []byte
+	etag string
+}
+
+// Encoded JSON together with its pre-compressed variants. Only ETag
+// validators are sent: time of cache fill isn't the time data changed
+// and differs between restarts and instances.
+type encodedJSON struct {
+	variants map[string]encodedVariant
+}
+
+// Brotli level for pre-compressed responses. Compression runs on cache
+// fill under the global cache lock, and the best level takes seconds for
+// all profiles while giving only a few percent smaller output.
+const brotliLevel = 6
+
+// Compressed only once on cache fill so use the best gzip level, it's
+// still fast.
+func newEncodedJSON(data []byte) (e *encodedJSON, err error) {
+	hash := hashBytes(data)
+	e = &encodedJSON{
+		variants: make(map[string]encodedVariant, 3),
+	}
+	e.variants[encIdentity] = encodedVariant{data, fmt.Sprintf("\"%s\"", hash)}
+
+	var buf bytes.Buffer
+	gw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return
+	}
+	if err = compress(gw, data); err != nil {
+		return
+	}
+	e.variants[encGzip] = encodedVariant{
+		append([]byte(nil), buf.Bytes()...),
+		fmt.Sprintf("\"%s-%s\"", hash, encGzip),
+	}
+
+	buf.Reset()
+	bw := brotli.NewWriterLevel(&buf, brotliLevel)
+	if err = compress(bw, data); err != nil {
+		return
+	}
+	e.variants[encBrotli] = encodedVariant{
+		append([]byte(nil), buf.Bytes()...),
+		fmt.Sprintf("\"%s-%s\"", hash, encBrotli),
+	}
+	return
+}
+
+func compress(w io.WriteCloser, data []byte) (err error) {
+	if _, err = w.Write(data); err != nil {
+		return
+	}
+	return w.Close()
+}
+
+// Select best supported coding accepted by the client.
+func negotiateEncoding(r *http.Request) string {
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		accepted[coding] = q
+	}
+	best := encIdentity
+	bestQ := 0.0
+	for _, coding := range compressedEncodings {
+		q, ok := accepted[coding]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > bestQ {
+			best = coding
+			bestQ = q
+		}
+	}
+	return best
+}
+
+// Serve the variant of encoded JSON matching client's Accept-Encoding.
+func serveEncodedVariant(w http.ResponseWriter, r *http.Request, e *encodedJSON) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	coding := negotiateEncoding(r)
+	if coding != encIdentity {
+		w.Header().Set("Content-Encoding", coding)
+	}
+	v := e.variants[coding]
+	serveEncodedJSON(w, r, v.data, v.etag, time.Time{})
+}
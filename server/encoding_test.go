@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := map[string]string{
+		"":                      encIdentity,
+		"gzip":                  encGzip,
+		"gzip, deflate, br":     encBrotli,
+		"br;q=0.5, gzip":        encGzip,
+		"br;q=0, gzip;q=0":      encIdentity,
+		"*":                     encBrotli,
+		"identity, deflate":     encIdentity,
+		"GZIP;q=0.8, *;q=0.1":   encGzip,
+		"gzip;q=0.8, br;q=0.80": encBrotli,
+	}
+	for header, expected := range tests {
+		r := httptest.NewRequest("GET", "/api/profiles", nil)
+		r.Header.Set("Accept-Encoding", header)
+		if actual := negotiateEncoding(r); actual != expected {
+			t.Errorf("%q: expected %s but got %s", header, expected, actual)
+		}
+	}
+}
+
+func TestServeEncodedVariant(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"name":"Chaeyoung"}`), 100)
+	e, err := newEncodedJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etags := make(map[string]bool)
+	for _, coding := range []string{encIdentity, encGzip, encBrotli} {
+		r := httptest.NewRequest("GET", "/api/profiles", nil)
+		r.Header.Set("Accept-Encoding", coding)
+		w := httptest.NewRecorder()
+		serveEncodedVariant(w, r, e)
+		res := w.Result()
+		if res.Header.Get("Vary") != "Accept-Encoding" {
+			t.Errorf("%s: missing Vary header", coding)
+		}
+		etags[res.Header.Get("ETag")] = true
+		body := res.Body
+		switch coding {
+		case encGzip:
+			if body, err = gzip.NewReader(body); err != nil {
+				t.Fatal(err)
+			}
+		case encBrotli:
+			body = ioutil.NopCloser(brotli.NewReader(body))
+		}
+		decoded, err := ioutil.ReadAll(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("%s: data mismatch", coding)
+		}
+
+		r.Header.Set("If-None-Match", res.Header.Get("ETag"))
+		w = httptest.NewRecorder()
+		serveEncodedVariant(w, r, e)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("%s: expected 304 but got %d", coding, w.Code)
+		}
+	}
+	// Changed data filled within the same second as the previous one
+	// mustn't be reported as not modified by time.
+	r := httptest.NewRequest("GET", "/api/profiles", nil)
+	r.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	w := httptest.NewRecorder()
+	serveEncodedVariant(w, r, e)
+	if w.Code != http.StatusOK || w.Header().Get("Last-Modified") != "" {
+		t.Errorf("unexpected time validation %d %v", w.Code, w.Header())
+	}
+	if len(etags) != 3 {
+		t.Errorf("expected distinct ETags per variant: %v", etags)
+	}
+}
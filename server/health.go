@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kpopnet/go-kpopnet/db"
+	"github.com/kpopnet/go-kpopnet/facerec"
+	"github.com/kpopnet/go-kpopnet/logging"
+)
+
+type readiness struct {
+	Ready bool   `json:"ready"`
+	DB    string `json:"db"`
+	facerec.Status
+}
+
+// Serve status without validators.
+func serveStatus(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		logging.Error(r.Context(), "error encoding status", "error", err)
+		status = http.StatusInternalServerError
+	}
+	setAPIHeaders(w)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// ServeHealth reports that process is alive.
+func ServeHealth(w http.ResponseWriter, r *http.Request) {
+	serveStatus(w, r, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Train data is only required when it's warmed on start, otherwise it's
+// loaded by the first recognition.
+func (res readiness) ready() bool {
+	return res.DB == "ok" && res.ModelsLoaded &&
+		(res.TrainDataLoaded || !res.WarmTrainData)
+}
+
+// ServeReady reports whether server is ready to handle requests: DB is
+// reachable, models and, if warming is enabled, train data are loaded.
+func ServeReady(w http.ResponseWriter, r *http.Request) {
+	res := readiness{DB: "ok", Status: facerec.GetStatus()}
+	if err := db.Ping(r.Context()); err != nil {
+		// Driver error can reveal connection details.
+		logging.Error(r.Context(), "database is unavailable", "error", err)
+		res.DB = "unavailable"
+	}
+	res.Ready = res.ready()
+	status := http.StatusOK
+	if !res.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	serveStatus(w, r, status, res)
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet/facerec"
+)
+
+func TestServeHealth(t *testing.T) {
+	router := createRouter()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+	if rec.Code != 200 {
+		t.Errorf("unexpected healthz status %d", rec.Code)
+	}
+
+	// Nothing is started in tests.
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/readyz", nil))
+	var res readiness
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != 503 || res.Ready || res.DB != "unavailable" || res.ModelsLoaded {
+		t.Errorf("unexpected readyz response %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		status facerec.Status
+		ready  bool
+	}{
+		{facerec.Status{}, false},
+		{facerec.Status{ModelsLoaded: true}, true},
+		{facerec.Status{ModelsLoaded: true, WarmTrainData: true}, false},
+		{facerec.Status{ModelsLoaded: true, WarmTrainData: true, TrainDataLoaded: true}, true},
+	}
+	for _, test := range tests {
+		if ready := (readiness{DB: "ok", Status: test.status}).ready(); ready != test.ready {
+			t.Errorf("%+v: expected ready %v but got %v", test.status, test.ready, ready)
+		}
+	}
+	if (readiness{DB: "down", Status: facerec.Status{ModelsLoaded: true}}).ready() {
+		t.Error("ready without DB")
+	}
+}
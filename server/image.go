@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/db"
+)
+
+const (
+	// Placeholder of image SHA1 in image path template.
+	sha1Placeholder = "{sha1}"
+
+	maxFetchRedirects   = 5
+	defaultFetchTimeout = 10 * time.Second
+)
+
+var fetchClient = &http.Client{
+	CheckRedirect: checkFetchRedirect,
+}
+
+// Timeout is applied per request since options can be reloaded.
+func getFetchTimeout() time.Duration {
+	if t := getOptions().FetchTimeout; t > 0 {
+		return time.Duration(t) * time.Second
+	}
+	return defaultFetchTimeout
+}
+
+// Check that URL points to one of allowed hosts. Host starting with dot
+// also allows all its subdomains.
+func isAllowedURL(u *url.URL, hosts []string) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range hosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || host == strings.TrimPrefix(allowed, ".") ||
+			(strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFetchRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxFetchRedirects {
+		return kpopnet.ErrFetchImage
+	}
+	if !isAllowedURL(req.URL, getOptions().FetchHosts) {
+		return kpopnet.ErrForbiddenURL
+	}
+	return nil
+}
+
+// Read no more than maxFileSize bytes.
+func readLimited(r io.Reader) (data []byte, err error) {
+	data, err = ioutil.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err == nil && int64(len(data)) > maxFileSize {
+		err = kpopnet.ErrTooLarge
+	}
+	return
+}
+
+// Fetch image from one of the allowed hosts.
+func fetchImage(ctx context.Context, rawurl string) (data []byte, err error) {
+	hosts := getOptions().FetchHosts
+	if len(hosts) == 0 {
+		err = kpopnet.ErrNotEnabled
+		return
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil || !isAllowedURL(u, hosts) {
+		err = kpopnet.ErrForbiddenURL
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, getFetchTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return
+	}
+	res, err := fetchClient.Do(req)
+	if err != nil {
+		if errors.Is(err, kpopnet.ErrForbiddenURL) {
+			err = kpopnet.ErrForbiddenURL
+		} else {
+			err = kpopnet.ErrFetchImage
+		}
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		err = kpopnet.ErrFetchImage
+		return
+	}
+	if res.ContentLength > maxFileSize {
+		err = kpopnet.ErrTooLarge
+		return
+	}
+	data, err = readLimited(res.Body)
+	if err != nil && err != kpopnet.ErrTooLarge {
+		err = kpopnet.ErrFetchImage
+	}
+	return
+}
+
+// Load already uploaded image from the local file store.
+func loadImage(ctx context.Context, sha1 string) (data []byte, err error) {
+	imagePath := getOptions().ImagePath
+	if imagePath == "" {
+		err = kpopnet.ErrNotEnabled
+		return
+	}
+	exists, err := db.ImageExists(ctx, sha1)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = kpopnet.ErrUnknownImage
+		return
+	}
+	fd, err := os.Open(strings.Replace(imagePath, sha1Placeholder, sha1, -1))
+	if os.IsNotExist(err) {
+		err = kpopnet.ErrUnknownImage
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer fd.Close()
+	return readLimited(fd)
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func TestIsAllowedURL(t *testing.T) {
+	hosts := []string{"example.com", ".kpop.net"}
+	tests := map[string]bool{
+		"https://example.com/a.jpg":      true,
+		"http://EXAMPLE.com:8080/a.jpg":  true,
+		"https://sub.example.com/a.jpg":  false,
+		"https://kpop.net/a.jpg":         true,
+		"https://img.kpop.net/a.jpg":     true,
+		"https://evilkpop.net/a.jpg":     false,
+		"ftp://example.com/a.jpg":        false,
+		"file:///etc/passwd":             false,
+		"https://example.com.evil/a.jpg": false,
+	}
+	for rawurl, expected := range tests {
+		u, _ := url.Parse(rawurl)
+		if actual := isAllowedURL(u, hosts); actual != expected {
+			t.Errorf("%s: expected %v", rawurl, expected)
+		}
+	}
+}
+
+func TestFetchImage(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	image := []byte("\xff\xd8 fake jpeg")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok.jpg":
+			w.Write(image)
+		case "/large.jpg":
+			w.Write(bytes.Repeat([]byte{0}, int(maxFileSize)+1))
+		case "/redirect":
+			http.Redirect(w, r, "http://localhost.invalid/ok.jpg", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	options.FetchHosts = nil
+	if _, err := fetchImage(context.Background(), ts.URL+"/ok.jpg"); err != kpopnet.ErrNotEnabled {
+		t.Errorf("expected fetching to be disabled: %v", err)
+	}
+
+	u, _ := url.Parse(ts.URL)
+	options.FetchHosts = []string{u.Hostname()}
+	data, err := fetchImage(context.Background(), ts.URL+"/ok.jpg")
+	if err != nil || !bytes.Equal(data, image) {
+		t.Errorf("bad fetch result: %v", err)
+	}
+	tests := map[string]error{
+		"/large.jpg":   kpopnet.ErrTooLarge,
+		"/missing.jpg": kpopnet.ErrFetchImage,
+		"/redirect":    kpopnet.ErrForbiddenURL,
+	}
+	for path, expected := range tests {
+		if _, err := fetchImage(context.Background(), ts.URL+path); !errors.Is(err, expected) {
+			t.Errorf("%s: expected %v but got %v", path, expected, err)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/facerec"
+	"github.com/kpopnet/go-kpopnet/logging"
+)
+
+const (
+	jobPending = "pending"
+	jobDone    = "done"
+	jobFailed  = "failed"
+
+	maxPendingJobs      = 100
+	maxFinishedJobs     = 1000
+	defaultJobRetention = time.Hour
+	jobCleanupInterval  = time.Minute
+)
+
+// Recognition job. Exported fields are returned to the client and sent
+// to the callback URL.
+type job struct {
+	ID         string          `json:"id"`
+	Status     string          `json:"status"`
+	Result     *facerec.Result `json:"result,omitempty"`
+	Error      *kpopnet.Error  `json:"error,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+
+	callbackURL string
+	// Closed when job is finished.
+	done chan struct{}
+}
+
+// Request to create job. Either image fields or uploaded file must be
+// provided.
+type jobRequest struct {
+	recognizeRequest
+	// URL to POST finished job to.
+	CallbackURL string `json:"callback_url"`
+}
+
+var (
+	jobsMu      sync.Mutex
+	jobs        = make(map[string]*job)
+	pendingJobs int
+	jobsWg      sync.WaitGroup
+	// IDs of finished jobs, oldest first.
+	finishedJobs []string
+	// Closed to stop cleanup of expired jobs.
+	jobsCleanupStop chan struct{}
+
+	// Callbacks are sent only to explicitly allowed hosts so redirects
+	// are not followed.
+	callbackClient = &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+)
+
+// Start cleanup of expired jobs unless it's already running.
+func setupJobs() {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	if jobsCleanupStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	jobsCleanupStop = stop
+	ticker := time.NewTicker(jobCleanupInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				removeExpiredJobs(now)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func stopJobsCleanup() {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	if jobsCleanupStop != nil {
+		close(jobsCleanupStop)
+		jobsCleanupStop = nil
+	}
+}
+
+func getJobRetention() time.Duration {
+	if t := getOptions().JobRetention; t > 0 {
+		return time.Duration(t) * time.Second
+	}
+	return defaultJobRetention
+}
+
+func newJobID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+func checkCallbackURL(rawurl string) (err error) {
+	if rawurl == "" {
+		return
+	}
+	hosts := getOptions().CallbackHosts
+	if len(hosts) == 0 {
+		return kpopnet.ErrNotEnabled
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil || !isAllowedURL(u, hosts) {
+		err = kpopnet.ErrForbiddenURL
+	}
+	return
+}
+
+// Register new job and start it in background. Image is loaded inside
+// the job so slow fetches don't block the request.
+func startJob(ctx context.Context, load func() ([]byte, error), callbackURL string) (j *job, err error) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	if pendingJobs >= maxPendingJobs {
+		err = kpopnet.ErrTooManyJobs
+		return
+	}
+	pendingJobs++
+	jobsWg.Add(1)
+	j = &job{
+		ID:          newJobID(),
+		Status:      jobPending,
+		CreatedAt:   time.Now().UTC(),
+		callbackURL: callbackURL,
+		done:        make(chan struct{}),
+	}
+	jobs[j.ID] = j
+	go runJob(ctx, j, load)
+	return
+}
+
+func runJob(ctx context.Context, j *job, load func() ([]byte, error)) {
+	imgData, err := load()
+	var res *facerec.Result
+	if err == nil {
+		res, err = facerec.RequestRecognize(ctx, imgData)
+	}
+
+	jobsMu.Lock()
+	pendingJobs--
+	finishedAt := time.Now().UTC()
+	j.FinishedAt = &finishedAt
+	if err != nil {
+		j.Status = jobFailed
+		j.Error = toAPIError(ctx, err)
+	} else {
+		j.Status = jobDone
+		j.Result = res
+	}
+	// Oldest finished jobs are evicted before expiration if there are
+	// too many.
+	finishedJobs = append(finishedJobs, j.ID)
+	for len(finishedJobs) > maxFinishedJobs {
+		delete(jobs, finishedJobs[0])
+		finishedJobs = finishedJobs[1:]
+	}
+	data, err := json.Marshal(j)
+	jobsMu.Unlock()
+
+	if err == nil && j.callbackURL != "" {
+		err = sendCallback(ctx, j.callbackURL, data)
+	}
+	if err != nil {
+		logging.Error(ctx, "error finishing job", "job_id", j.ID, "error", err)
+	}
+	close(j.done)
+	jobsWg.Done()
+}
+
+// Wait for all running jobs including their callbacks.
+func waitJobs(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		jobsWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func sendCallback(ctx context.Context, callbackURL string, data []byte) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, getFetchTimeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", callbackURL, bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := callbackClient.Do(req)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		err = fmt.Errorf("callback returned %s", res.Status)
+	}
+	return
+}
+
+// Get encoded job state.
+func getJob(id string) (data []byte, err error) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	j, ok := jobs[id]
+	if !ok {
+		err = kpopnet.ErrUnknownJob
+		return
+	}
+	return json.Marshal(j)
+}
+
+// Remove jobs finished more than retention period ago.
+func removeExpiredJobs(now time.Time) {
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	retention := getJobRetention()
+	for id, j := range jobs {
+		if j.FinishedAt != nil && now.Sub(*j.FinishedAt) > retention {
+			delete(jobs, id)
+		}
+	}
+	kept := finishedJobs[:0]
+	for _, id := range finishedJobs {
+		if _, ok := jobs[id]; ok {
+			kept = append(kept, id)
+		}
+	}
+	finishedJobs = kept
+}
+
+// Job state changes so it's served without validators.
+func serveJob(w http.ResponseWriter, status int, data []byte) {
+	setAPIHeaders(w)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// ServeCreateJob starts recognition of the image in background and
+// returns job ID. Accepts the same input as ServeRecognize plus optional
+// callback URL which receives the finished job.
+func ServeCreateJob(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	// Job outlives the request so only its ID is kept.
+	ctx := logging.WithRequestID(context.Background(), logging.RequestID(r.Context()))
+	var req jobRequest
+	var load func() ([]byte, error)
+	ctype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ctype == "application/json" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			serveError(w, r, kpopnet.ErrParseJSON)
+			return
+		}
+		load = func() ([]byte, error) { return req.load(ctx) }
+	} else {
+		if err := r.ParseMultipartForm(0); err != nil {
+			serveError(w, r, kpopnet.ErrParseForm)
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+		fhs := r.MultipartForm.File["files[]"]
+		if len(fhs) != 1 {
+			serveError(w, r, kpopnet.ErrParseFile)
+			return
+		}
+		imgData, err := readBatchFile(batchFile{fhs[0].Filename, func() (io.ReadCloser, error) {
+			return fhs[0].Open()
+		}})
+		if err != nil {
+			serveError(w, r, err)
+			return
+		}
+		req.CallbackURL = r.FormValue("callback_url")
+		load = func() ([]byte, error) { return imgData, nil }
+	}
+	if err := checkCallbackURL(req.CallbackURL); err != nil {
+		serveError(w, r, err)
+		return
+	}
+	j, err := startJob(ctx, load, req.CallbackURL)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	data, err := getJob(j.ID)
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/jobs/"+j.ID)
+	serveJob(w, http.StatusAccepted, data)
+}
+
+// ServeJob returns current state of the job.
+func ServeJob(w http.ResponseWriter, r *http.Request) {
+	data, err := getJob(getParam(r, "id"))
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJob(w, http.StatusOK, data)
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func TestCheckCallbackURL(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.CallbackHosts = nil
+	if err := checkCallbackURL(""); err != nil {
+		t.Errorf("empty callback should be allowed: %v", err)
+	}
+	if err := checkCallbackURL("https://example.com/cb"); err != kpopnet.ErrNotEnabled {
+		t.Errorf("expected callbacks to be disabled: %v", err)
+	}
+	options.CallbackHosts = []string{"example.com"}
+	if err := checkCallbackURL("https://example.com/cb"); err != nil {
+		t.Errorf("expected callback to be allowed: %v", err)
+	}
+	if err := checkCallbackURL("https://evil.com/cb"); err != kpopnet.ErrForbiddenURL {
+		t.Errorf("expected callback to be forbidden: %v", err)
+	}
+}
+
+func TestJobCallback(t *testing.T) {
+	received := make(chan job, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var j job
+		data, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &j); err != nil {
+			t.Error(err)
+		}
+		received <- j
+	}))
+	defer ts.Close()
+
+	load := func() ([]byte, error) { return nil, kpopnet.ErrUnknownImage }
+	j, err := startJob(context.Background(), load, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-j.done
+	cb := <-received
+	if cb.ID != j.ID || cb.Status != jobFailed || !errors.Is(cb.Error, kpopnet.ErrUnknownImage) {
+		t.Errorf("bad callback: %+v", cb)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/jobs/"+j.ID, nil)
+	createRouter().ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+	var state job
+	json.Unmarshal(rec.Body.Bytes(), &state)
+	if state.Status != jobFailed || state.FinishedAt == nil {
+		t.Errorf("bad job state: %s", rec.Body)
+	}
+}
+
+func TestRemoveExpiredJobs(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.JobRetention = 60
+	load := func() ([]byte, error) { return nil, kpopnet.ErrBadImage }
+	j, err := startJob(context.Background(), load, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-j.done
+	removeExpiredJobs(time.Now().Add(30 * time.Second))
+	if _, err := getJob(j.ID); err != nil {
+		t.Errorf("job removed too early: %v", err)
+	}
+	removeExpiredJobs(time.Now().Add(2 * time.Minute))
+	if _, err := getJob(j.ID); err != kpopnet.ErrUnknownJob {
+		t.Errorf("expected job to expire: %v", err)
+	}
+}
+
+func TestServeCreateJobForbiddenCallback(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.CallbackHosts = []string{"example.com"}
+	body := `{"url": "https://example.com/a.jpg", "callback_url": "http://127.0.0.1/cb"}`
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/jobs", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	createRouter().ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("unexpected status %d", rec.Code)
+	}
+}
+
+func TestMaxFinishedJobs(t *testing.T) {
+	load := func() ([]byte, error) { return nil, kpopnet.ErrBadImage }
+	var first *job
+	for i := 0; i <= maxFinishedJobs; i++ {
+		j, err := startJob(context.Background(), load, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-j.done
+		if first == nil {
+			first = j
+		}
+	}
+	if _, err := getJob(first.ID); err != kpopnet.ErrUnknownJob {
+		t.Errorf("expected oldest job to be evicted: %v", err)
+	}
+	jobsMu.Lock()
+	n := len(finishedJobs)
+	jobsMu.Unlock()
+	if n > maxFinishedJobs {
+		t.Errorf("too many finished jobs kept: %d", n)
+	}
+}
+
+func TestSetupJobsOnce(t *testing.T) {
+	setupJobs()
+	jobsMu.Lock()
+	stop := jobsCleanupStop
+	jobsMu.Unlock()
+	setupJobs()
+	jobsMu.Lock()
+	same := jobsCleanupStop == stop
+	jobsMu.Unlock()
+	if !same {
+		t.Error("cleanup started twice")
+	}
+	stopJobsCleanup()
+	select {
+	case <-stop:
+	default:
+		t.Error("cleanup isn't stopped")
+	}
+}
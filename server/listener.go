@@ -0,0 +1,118 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Certificate loader which picks up renewed certificate files.
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (cr *certReloader, err error) {
+	cr = &certReloader{certFile: certFile, keyFile: keyFile}
+	_, err = cr.getCertificate(nil)
+	return
+}
+
+// Files' modification time, the latest one.
+func (cr *certReloader) filesModTime() (modTime time.Time, err error) {
+	for _, name := range []string{cr.certFile, cr.keyFile} {
+		var fi os.FileInfo
+		if fi, err = os.Stat(name); err != nil {
+			return
+		}
+		if fi.ModTime().After(modTime) {
+			modTime = fi.ModTime()
+		}
+	}
+	return
+}
+
+// Reload certificate if files were changed. Previous certificate is
+// kept on errors so half-written files don't break the server.
+func (cr *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	modTime, err := cr.filesModTime()
+	if err == nil && (cr.cert == nil || !modTime.Equal(cr.modTime)) {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(cr.certFile, cr.keyFile); err == nil {
+			cr.cert = &cert
+			cr.modTime = modTime
+		}
+	}
+	if cr.cert == nil {
+		return nil, fmt.Errorf("error loading certificate: %v", err)
+	}
+	return cr.cert, nil
+}
+
+// Listen on Unix socket or TCP address, with TLS if it's configured.
+func listen(opts Options) (ln net.Listener, err error) {
+	if opts.Socket != "" {
+		ln, err = listenUnix(opts.Socket, opts.SocketMode)
+	} else {
+		ln, err = net.Listen("tcp", opts.Address)
+	}
+	if err != nil || opts.TLSCert == "" {
+		return
+	}
+	cr, err := newCertReloader(opts.TLSCert, opts.TLSKey)
+	if err != nil {
+		ln.Close()
+		return
+	}
+	ln = tls.NewListener(ln, &tls.Config{
+		GetCertificate: cr.getCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	})
+	return
+}
+
+// Listen on Unix socket, removing stale one left by killed process.
+// Socket of the running process is never taken over.
+func listenUnix(path string, mode os.FileMode) (ln net.Listener, err error) {
+	if fi, statErr := os.Stat(path); statErr == nil && fi.Mode()&os.ModeSocket != 0 {
+		conn, dialErr := net.Dial("unix", path)
+		if dialErr == nil {
+			conn.Close()
+			err = fmt.Errorf("socket %s is in use", path)
+			return
+		}
+		if !errors.Is(dialErr, syscall.ECONNREFUSED) {
+			err = dialErr
+			return
+		}
+		os.Remove(path)
+	}
+	listen := func() (err error) {
+		ln, err = net.Listen("unix", path)
+		return
+	}
+	if mode == 0 {
+		err = listen()
+		return
+	}
+	// Create socket with the right permissions at once so there is no
+	// window when it's accessible to others.
+	if err = withUmask(int(^mode.Perm()&0777), listen); err != nil {
+		return
+	}
+	if err = os.Chmod(path, mode); err != nil {
+		ln.Close()
+	}
+	return
+}
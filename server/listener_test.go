@@ -0,0 +1,120 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Write self-signed certificate with given serial number.
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	for name, data := range map[string][]byte{certFile: certPem, keyFile: keyPem} {
+		if err := ioutil.WriteFile(name, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(name, modTime, modTime)
+	}
+}
+
+func certSerial(t *testing.T, cr *certReloader) int64 {
+	cert, err := cr.getCertificate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.SerialNumber.Int64()
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kpopnet-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	modTime := time.Now().Add(-time.Hour)
+
+	writeTestCert(t, certFile, keyFile, 1, modTime)
+	cr, err := newCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if serial := certSerial(t, cr); serial != 1 {
+		t.Errorf("expected first certificate but got %d", serial)
+	}
+
+	writeTestCert(t, certFile, keyFile, 2, modTime.Add(time.Minute))
+	if serial := certSerial(t, cr); serial != 2 {
+		t.Errorf("expected renewed certificate but got %d", serial)
+	}
+
+	ioutil.WriteFile(certFile, []byte("broken"), 0600)
+	if serial := certSerial(t, cr); serial != 2 {
+		t.Errorf("expected previous certificate to be kept but got %d", serial)
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kpopnet-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "kpopnet.sock")
+	for i := 0; i < 2; i++ {
+		ln, err := listenUnix(path, 0660)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0660 {
+			t.Errorf("unexpected socket mode %v", fi.Mode())
+		}
+		if i == 0 {
+			// Socket of the running process isn't taken over.
+			if ln2, err := listenUnix(path, 0660); err == nil {
+				ln2.Close()
+				t.Error("live socket is taken over")
+			}
+			// Emulate killed process leaving socket behind.
+			ln.(interface{ SetUnlinkOnClose(bool) }).SetUnlinkOnClose(false)
+		}
+		ln.Close()
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet/logging"
+	"github.com/kpopnet/go-kpopnet/metrics"
+
+	"github.com/dimfeld/httptreemux/v5"
+)
+
+// Response writer remembering status code.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush is needed for streaming responses.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Count requests by route and status. Route is known only after lookup
+// so it wraps route handlers rather than the whole group.
+func instrument(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w}
+		next(sw, r)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		route := httptreemux.ContextRoute(r.Context())
+		metrics.Requests.WithLabelValues(route, r.Method, strconv.Itoa(sw.status)).Inc()
+		metrics.RequestDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Group which instruments all its handlers. Every route also gets
+// OPTIONS handler so group middleware can answer CORS preflight.
+type instrumentedGroup struct {
+	*httptreemux.ContextGroup
+	// Paths which already have OPTIONS handler.
+	optionsPaths map[string]bool
+}
+
+func newInstrumentedGroup(g *httptreemux.ContextGroup) instrumentedGroup {
+	return instrumentedGroup{g, make(map[string]bool)}
+}
+
+func (g instrumentedGroup) GET(path string, handler http.HandlerFunc) {
+	g.ContextGroup.GET(path, instrument(handler))
+	g.handleOptions(path)
+}
+
+func (g instrumentedGroup) POST(path string, handler http.HandlerFunc) {
+	g.ContextGroup.POST(path, instrument(handler))
+	g.handleOptions(path)
+}
+
+func (g instrumentedGroup) handleOptions(path string) {
+	if !g.optionsPaths[path] {
+		g.ContextGroup.OPTIONS(path, serveOptions)
+		g.optionsPaths[path] = true
+	}
+}
+
+// Client-provided request IDs are accepted only in this form.
+var requestIDRe = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Assign ID to the request, passing it to logs via context, and write
+// access log line.
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := r.Header.Get("X-Request-ID")
+		if !requestIDRe.MatchString(id) {
+			id = logging.NewRequestID()
+		}
+		w.Header().Set("X-Request-ID", id)
+		r = r.WithContext(logging.WithRequestID(r.Context(), id))
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, r)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		logging.Info(r.Context(), "request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", sw.status,
+			"took_ms", time.Since(start).Seconds()*1000,
+			"remote", r.RemoteAddr)
+	})
+}
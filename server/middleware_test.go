@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	router := createRouter()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/search", nil))
+	if rec.Code != 400 {
+		t.Fatalf("unexpected status %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	expected := `kpopnet_http_requests_total{method="GET",route="/api/search",status="400"}`
+	if rec.Code != 200 || !strings.Contains(rec.Body.String(), expected) {
+		t.Errorf("no request counter in metrics:\n%s", rec.Body)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	router := createRouter()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/healthz", nil)
+	req.Header.Set("X-Request-ID", "client-id.1")
+	router.ServeHTTP(rec, req)
+	if id := rec.Header().Get("X-Request-ID"); id != "client-id.1" {
+		t.Errorf("expected client request ID but got %q", id)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/healthz", nil)
+	req.Header.Set("X-Request-ID", "bad id\n")
+	router.ServeHTTP(rec, req)
+	if id := rec.Header().Get("X-Request-ID"); len(id) != 16 {
+		t.Errorf("expected generated request ID but got %q", id)
+	}
+}
@@ -0,0 +1,26 @@
+//go:generate go run github.com/kevinburke/go-bindata/go-bindata -o bin_data.go --pkg server --nometadata openapi.json
+
+package server
+
+import (
+	"net/http"
+	"sync"
+)
+
+var (
+	openAPIOnce sync.Once
+	openAPIDoc  *encodedJSON
+	openAPIErr  error
+)
+
+// ServeOpenAPI returns OpenAPI document describing all routes.
+func ServeOpenAPI(w http.ResponseWriter, r *http.Request) {
+	openAPIOnce.Do(func() {
+		openAPIDoc, openAPIErr = newEncodedJSON(MustAsset("openapi.json"))
+	})
+	if openAPIErr != nil {
+		serveError(w, r, openAPIErr)
+		return
+	}
+	serveEncodedVariant(w, r, openAPIDoc)
+}
@@ -0,0 +1,387 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/cache"
+	"github.com/kpopnet/go-kpopnet/facerec"
+)
+
+type jsonObject = map[string]interface{}
+
+func loadOpenAPI(t *testing.T) (spec jsonObject) {
+	if err := json.Unmarshal(MustAsset("openapi.json"), &spec); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// Follow local reference like #/components/schemas/Idol.
+func resolveRef(spec, v jsonObject) jsonObject {
+	for {
+		ref, ok := v["$ref"].(string)
+		if !ok {
+			return v
+		}
+		v = spec
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			v, _ = v[part].(jsonObject)
+		}
+	}
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case jsonObject:
+		return "object"
+	}
+	return "unknown"
+}
+
+// Check value against subset of JSON Schema used in the spec.
+func validateSchema(spec, schema jsonObject, v interface{}, path string) error {
+	schema = resolveRef(spec, schema)
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			if err := validateSchema(spec, sub.(jsonObject), v, path); err != nil {
+				return err
+			}
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if validateSchema(spec, sub.(jsonObject), v, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d of oneOf schemas", path, matched)
+		}
+	}
+	if typ, ok := schema["type"].(string); ok {
+		actual := jsonType(v)
+		if actual != typ && !(typ == "number" && actual == "integer") {
+			return fmt.Errorf("%s: expected %s but got %s", path, typ, actual)
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, item := range enum {
+			found = found || reflect.DeepEqual(item, v)
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not in %v", path, v, enum)
+		}
+	}
+	switch v := v.(type) {
+	case jsonObject:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := v[name.(string)]; !ok {
+					return fmt.Errorf("%s: missing %s", path, name)
+				}
+			}
+		}
+		props, _ := schema["properties"].(jsonObject)
+		for name, val := range v {
+			propSchema, ok := props[name].(jsonObject)
+			if !ok {
+				switch extra := schema["additionalProperties"].(type) {
+				case bool:
+					if !extra {
+						return fmt.Errorf("%s: unexpected %s", path, name)
+					}
+					continue
+				case jsonObject:
+					propSchema = extra
+				default:
+					continue
+				}
+			}
+			if err := validateSchema(spec, propSchema, val, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if min, ok := schema["minItems"].(float64); ok && float64(len(v)) < min {
+			return fmt.Errorf("%s: too few items", path)
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(v)) > max {
+			return fmt.Errorf("%s: too many items", path)
+		}
+		if items, ok := schema["items"].(jsonObject); ok {
+			for i, item := range v {
+				if err := validateSchema(spec, items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Find path template matching the request path, e.g. /api/idols/{id}.
+func matchSpecPath(spec jsonObject, path string) (jsonObject, bool) {
+	parts := strings.Split(path, "/")
+	for tmpl, item := range spec["paths"].(jsonObject) {
+		tmplParts := strings.Split(tmpl, "/")
+		if len(tmplParts) != len(parts) {
+			continue
+		}
+		matched := true
+		for i, part := range tmplParts {
+			if !strings.HasPrefix(part, "{") && part != parts[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return item.(jsonObject), true
+		}
+	}
+	return nil, false
+}
+
+// Check response against operation description in the spec.
+func validateResponse(spec jsonObject, method, path string, rec *httptest.ResponseRecorder) error {
+	item, ok := matchSpecPath(spec, path)
+	if !ok {
+		return fmt.Errorf("%s is not documented", path)
+	}
+	op, ok := item[strings.ToLower(method)].(jsonObject)
+	if !ok {
+		return fmt.Errorf("%s %s is not documented", method, path)
+	}
+	responses := op["responses"].(jsonObject)
+	resp, ok := responses[strconv.Itoa(rec.Code)].(jsonObject)
+	if !ok {
+		if resp, ok = responses["default"].(jsonObject); !ok {
+			return fmt.Errorf("status %d is not documented", rec.Code)
+		}
+	}
+	resp = resolveRef(spec, resp)
+	headers, _ := resp["headers"].(jsonObject)
+	for name := range headers {
+		if rec.Header().Get(name) == "" {
+			return fmt.Errorf("missing %s header", name)
+		}
+	}
+	content, _ := resp["content"].(jsonObject)
+	if content == nil {
+		if rec.Body.Len() != 0 {
+			return fmt.Errorf("unexpected body")
+		}
+		return nil
+	}
+	ctype, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	media, ok := content[ctype].(jsonObject)
+	if !ok {
+		return fmt.Errorf("content type %s is not documented", ctype)
+	}
+	schema := media["schema"].(jsonObject)
+	var values []string
+	switch ctype {
+	case "application/json":
+		values = []string{rec.Body.String()}
+	case "application/x-ndjson":
+		values = strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	default:
+		return nil
+	}
+	for _, s := range values {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			return err
+		}
+		if err := validateSchema(spec, schema, v, "body"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	spec := loadOpenAPI(t)
+	mux := createMux()
+	for tmpl, item := range spec["paths"].(jsonObject) {
+		path := strings.NewReplacer("{id}", "x").Replace(tmpl)
+		for method := range item.(jsonObject) {
+			req := httptest.NewRequest(strings.ToUpper(method), path, nil)
+			if _, found := mux.Lookup(httptest.NewRecorder(), req); !found {
+				t.Errorf("%s %s is documented but not routed", method, tmpl)
+			}
+		}
+	}
+}
+
+func batchBody(t *testing.T) (string, string) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for _, name := range []string{"a.jpg", "b.jpg"} {
+		fw, err := mw.CreateFormFile("files[]", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte("image"))
+	}
+	mw.Close()
+	return buf.String(), mw.FormDataContentType()
+}
+
+func TestOpenAPIResponses(t *testing.T) {
+	spec := loadOpenAPI(t)
+	defer fakeAPIKeys(testKeys)()
+	defer func(opts Options) { options = opts }(options)
+	defer func(l *limiter) { ipLimiter = l }(ipLimiter)
+	options.EnableAdmin = false
+	options.IPRateLimit = RateLimit{PerMinute: 1}
+	options.CallbackHosts = []string{"example.com"}
+
+	// Serve profiles without DB.
+	ps, _ := testProfiles()
+	cache.ClearProfilesCache()
+	defer cache.ClearProfilesCache()
+	cache.Cached(cache.ProfileDataCacheKey, func() (interface{}, error) {
+		return ps, nil
+	})
+
+	finished := time.Now()
+	done := &job{
+		ID:     "openapi-test-job",
+		Status: jobDone,
+		Result: &facerec.Result{
+			IdolID:    "i1",
+			Distance:  0.3,
+			Rectangle: facerec.Rect{X: 1, Y: 2, Width: 100, Height: 100},
+			Landmarks: []facerec.Point{{X: 10, Y: 10}, {X: 20, Y: 10}},
+			Image:     facerec.Size{Width: 640, Height: 480},
+		},
+		CreatedAt:  finished.Add(-time.Second),
+		FinishedAt: &finished,
+	}
+	jobsMu.Lock()
+	jobs[done.ID] = done
+	jobsMu.Unlock()
+	defer func() {
+		jobsMu.Lock()
+		delete(jobs, done.ID)
+		jobsMu.Unlock()
+	}()
+
+	batch, batchType := batchBody(t)
+	tests := []struct {
+		method string
+		target string
+		ctype  string
+		body   string
+		key    string
+		status int
+	}{
+		{"GET", "/api/openapi.json", "", "", "", 200},
+		{"GET", "/api/profiles", "", "", "", 200},
+		{"GET", "/api/profiles?sort=name&limit=2", "", "", "", 200},
+		{"GET", "/api/profiles?fields=name", "", "", "", 200},
+		{"GET", "/api/profiles?limit=x", "", "", "", 400},
+		{"GET", "/api/search?q=twice", "", "", "", 200},
+		{"GET", "/api/search", "", "", "", 400},
+		{"GET", "/api/idols/x/similar?limit=x", "", "", "", 400},
+		{"GET", "/api/jobs/" + done.ID, "", "", "", 200},
+		{"GET", "/api/jobs/unknown", "", "", "", 404},
+		{"POST", "/api/recognize", "application/json", `{}`, "", 400},
+		// Rate limit is exhausted by the previous request.
+		{"POST", "/api/recognize", "application/json", `{}`, "", 429},
+		{"POST", "/api/recognize/batch", batchType, batch, "", 200},
+		{"POST", "/api/verify", "application/json", `{"images": []}`, "admin", 400},
+		{"POST", "/api/jobs", "application/json", `{"url": "x", "callback_url": "https://evil.com"}`, "admin", 403},
+		{"GET", "/api/admin/faces/audit", "", "", "admin", 501},
+		{"POST", "/api/admin/faces/label", "application/json", `{}`, "reader", 501},
+		{"GET", "/healthz", "", "", "", 200},
+		{"GET", "/readyz", "", "", "", 503},
+		{"GET", "/metrics", "", "", "", 200},
+	}
+	ipLimiter = newLimiter()
+	router := createRouter()
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		if test.ctype != "" {
+			req.Header.Set("Content-Type", test.ctype)
+		}
+		if test.key != "" {
+			req.Header.Set("X-API-Key", test.key)
+		}
+		router.ServeHTTP(rec, req)
+		name := test.method + " " + test.target
+		if rec.Code != test.status {
+			t.Errorf("%s: unexpected status %d: %s", name, rec.Code, rec.Body)
+			continue
+		}
+		if err := validateResponse(spec, test.method, req.URL.Path, rec); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+// Values which can't be produced by handlers without models or DB are
+// checked as encoded by the server.
+func TestOpenAPISchemas(t *testing.T) {
+	spec := loadOpenAPI(t)
+	tests := []struct {
+		schema string
+		v      interface{}
+	}{
+		{"Verification", &facerec.Verification{Match: true, Distance: 0.3, MeanDistance: 0.4, Samples: 5, Threshold: facerec.MatchThreshold}},
+		{"Similar", &facerec.Similar{IdolID: "i1", Distance: 0.5}},
+		{"AuditReport", &facerec.AuditReport{
+			Faces:       3,
+			Mislabelled: []facerec.MislabelledFace{{FaceID: 1, IdolID: "i1", NeighbourIdolID: "i2", NeighbourFaceIDs: []int64{2, 3}}},
+			Duplicates:  []facerec.DuplicateFaces{{FaceIDs: [2]int64{1, 2}, IdolIDs: [2]string{"i1", "i2"}, Distance: 0.1}},
+			FewSamples:  []facerec.FewSamplesIdol{{IdolID: "i3", FaceIDs: []int64{3}}},
+		}},
+		{"ClusterReport", &facerec.ClusterReport{
+			Faces:    3,
+			Clusters: []*facerec.Cluster{{FaceIDs: []int64{1, 2}, ImageIDs: []string{"a", "b"}, Labels: map[string]int{"i1": 2}}},
+			Noise:    1,
+		}},
+		{"LabelResponse", &labelResponse{Idol: &kpopnet.Idol{ID: "i4", BandID: "b1", Name: "New"}, IdolID: "i4", Labelled: 2}},
+		{"Error", kpopnet.ErrNoSingleFace.WithDetails(map[string]interface{}{"faces": 2})},
+	}
+	for _, test := range tests {
+		data, err := json.Marshal(test.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v interface{}
+		json.Unmarshal(data, &v)
+		schema := jsonObject{"$ref": "#/components/schemas/" + test.schema}
+		if err := validateSchema(spec, schema, v, test.schema); err != nil {
+			t.Error(err)
+		}
+	}
+}
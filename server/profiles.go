@@ -0,0 +1,315 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+// Query parameters of profiles request which result into a page instead
+// of the full cached response.
+var pageParams = []string{
+	"offset", "limit", "band", "agency", "debut_year", "has_preview", "sort", "fields",
+}
+
+// Options of profiles page.
+type profileQuery struct {
+	offset     int
+	limit      int
+	bandID     string
+	agency     string
+	debutYear  string
+	hasPreview *bool
+	sortKey    string
+	sortDesc   bool
+	fields     map[string]bool
+}
+
+// Filtered, sorted and paginated profiles.
+type profilesPage struct {
+	Revision     int64                 `json:"revision"`
+	Bands        []interface{}         `json:"bands"`
+	Idols        []interface{}         `json:"idols"`
+	Memberships  []*kpopnet.Membership `json:"memberships"`
+	DeletedBands []string              `json:"deleted_bands,omitempty"`
+	DeletedIdols []string              `json:"deleted_idols,omitempty"`
+	TotalBands   int                   `json:"total_bands"`
+	TotalIdols   int                   `json:"total_idols"`
+}
+
+func isPageQuery(query url.Values) bool {
+	for _, name := range pageParams {
+		if _, ok := query[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse non-negative integer parameter, zero if missing.
+func parseUint(query url.Values, name string) (n int, ok bool) {
+	s := query.Get(name)
+	if s == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil && n >= 0
+}
+
+func parseProfileQuery(query url.Values) (q profileQuery, err error) {
+	var ok bool
+	if q.offset, ok = parseUint(query, "offset"); !ok {
+		err = kpopnet.ErrBadQuery
+		return
+	}
+	if q.limit, ok = parseUint(query, "limit"); !ok {
+		err = kpopnet.ErrBadQuery
+		return
+	}
+	year, ok := parseUint(query, "debut_year")
+	if !ok || year > 9999 {
+		err = kpopnet.ErrBadQuery
+		return
+	}
+	if year > 0 {
+		q.debutYear = fmt.Sprintf("%04d", year)
+	}
+	if s := query.Get("has_preview"); s != "" {
+		hasPreview, err2 := strconv.ParseBool(s)
+		if err2 != nil {
+			err = kpopnet.ErrBadQuery
+			return
+		}
+		q.hasPreview = &hasPreview
+	}
+	q.sortKey = query.Get("sort")
+	if strings.HasPrefix(q.sortKey, "-") {
+		q.sortKey = q.sortKey[1:]
+		q.sortDesc = true
+	}
+	switch q.sortKey {
+	case "", "name", "birth_date", "debut_date":
+		// Do nothing.
+	default:
+		err = kpopnet.ErrBadQuery
+		return
+	}
+	if s := query.Get("fields"); s != "" {
+		// ID is always needed to identify the entity.
+		q.fields = map[string]bool{"id": true}
+		for _, field := range strings.Split(s, ",") {
+			q.fields[strings.TrimSpace(field)] = true
+		}
+	}
+	q.bandID = query.Get("band")
+	q.agency = query.Get("agency")
+	return
+}
+
+// Apply query to profiles. bandByID should contain all known bands to
+// be able to filter idols by band properties.
+func (q profileQuery) apply(ps *kpopnet.Profiles, bandByID map[string]*kpopnet.Band) (page *profilesPage, err error) {
+	bands := make([]*kpopnet.Band, 0)
+	for _, band := range ps.Bands {
+		if q.matchBand(band) {
+			bands = append(bands, band)
+		}
+	}
+	idolBands := make(map[string][]string)
+	for _, m := range ps.Memberships {
+		idolBands[m.IdolID] = append(idolBands[m.IdolID], m.BandID)
+	}
+	idols := make([]*kpopnet.Idol, 0)
+	for _, idol := range ps.Idols {
+		if q.matchIdol(idol, idolBands[idol.ID], bandByID) {
+			idols = append(idols, idol)
+		}
+	}
+	q.sortBands(bands)
+	q.sortIdols(idols)
+
+	page = &profilesPage{
+		Revision:     ps.Revision,
+		Bands:        make([]interface{}, 0),
+		Idols:        make([]interface{}, 0),
+		Memberships:  make([]*kpopnet.Membership, 0),
+		DeletedBands: ps.DeletedBands,
+		DeletedIdols: ps.DeletedIdols,
+		TotalBands:   len(bands),
+		TotalIdols:   len(idols),
+	}
+	bandFrom, bandTo := q.pageBounds(len(bands))
+	for _, band := range bands[bandFrom:bandTo] {
+		var v interface{}
+		if v, err = q.selectFields(band); err != nil {
+			return
+		}
+		page.Bands = append(page.Bands, v)
+	}
+	idolFrom, idolTo := q.pageBounds(len(idols))
+	pageIdols := make(map[string]bool, idolTo-idolFrom)
+	for _, idol := range idols[idolFrom:idolTo] {
+		var v interface{}
+		if v, err = q.selectFields(idol); err != nil {
+			return
+		}
+		page.Idols = append(page.Idols, v)
+		pageIdols[idol.ID] = true
+	}
+	for _, m := range ps.Memberships {
+		if pageIdols[m.IdolID] {
+			page.Memberships = append(page.Memberships, m)
+		}
+	}
+	return
+}
+
+func (q profileQuery) matchBand(band *kpopnet.Band) bool {
+	if q.bandID != "" && band.ID != q.bandID {
+		return false
+	}
+	if q.agency != "" && !strings.EqualFold(band.AgencyName, q.agency) {
+		return false
+	}
+	if q.debutYear != "" && !strings.HasPrefix(band.DebutDate, q.debutYear) {
+		return false
+	}
+	return true
+}
+
+func (q profileQuery) matchIdol(idol *kpopnet.Idol, bandIDs []string, bandByID map[string]*kpopnet.Band) bool {
+	if len(bandIDs) == 0 {
+		bandIDs = []string{idol.BandID}
+	}
+	if q.bandID != "" && !contains(bandIDs, q.bandID) {
+		return false
+	}
+	if q.agency != "" {
+		found := false
+		for _, id := range bandIDs {
+			if band, ok := bandByID[id]; ok && strings.EqualFold(band.AgencyName, q.agency) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if q.debutYear != "" {
+		debutDate := idol.DebutDate
+		if band, ok := bandByID[idol.BandID]; ok && debutDate == "" {
+			debutDate = band.DebutDate
+		}
+		if !strings.HasPrefix(debutDate, q.debutYear) {
+			return false
+		}
+	}
+	if q.hasPreview != nil && (idol.ImageID != "") != *q.hasPreview {
+		return false
+	}
+	return true
+}
+
+// Entities without the sort key always go last.
+func (q profileQuery) less(a, b string) bool {
+	switch {
+	case a == b:
+		return false
+	case a == "":
+		return false
+	case b == "":
+		return true
+	case q.sortDesc:
+		return a > b
+	default:
+		return a < b
+	}
+}
+
+func (q profileQuery) sortBands(bands []*kpopnet.Band) {
+	if q.sortKey == "" {
+		return
+	}
+	key := func(band *kpopnet.Band) string {
+		switch q.sortKey {
+		case "name":
+			return strings.ToLower(band.Name)
+		case "debut_date":
+			return band.DebutDate
+		}
+		return ""
+	}
+	sort.SliceStable(bands, func(i, j int) bool {
+		return q.less(key(bands[i]), key(bands[j]))
+	})
+}
+
+func (q profileQuery) sortIdols(idols []*kpopnet.Idol) {
+	if q.sortKey == "" {
+		return
+	}
+	key := func(idol *kpopnet.Idol) string {
+		switch q.sortKey {
+		case "name":
+			return strings.ToLower(idol.Name)
+		case "birth_date":
+			return idol.BirthDate
+		case "debut_date":
+			return idol.DebutDate
+		}
+		return ""
+	}
+	sort.SliceStable(idols, func(i, j int) bool {
+		return q.less(key(idols[i]), key(idols[j]))
+	})
+}
+
+// Zero limit means no limit.
+func (q profileQuery) pageBounds(total int) (from int, to int) {
+	from = q.offset
+	if from > total {
+		from = total
+	}
+	to = total
+	if q.limit > 0 && from+q.limit < total {
+		to = from + q.limit
+	}
+	return
+}
+
+// Leave only requested fields of the entity.
+func (q profileQuery) selectFields(v interface{}) (interface{}, error) {
+	if q.fields == nil {
+		return v, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	selected := make(map[string]json.RawMessage, len(q.fields))
+	for key, val := range all {
+		if q.fields[key] {
+			selected[key] = val
+		}
+	}
+	return selected, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/kpopnet/go-kpopnet"
+)
+
+func testProfiles() (*kpopnet.Profiles, map[string]*kpopnet.Band) {
+	bands := []*kpopnet.Band{
+		{ID: "b1", Name: "Twice", AgencyName: "JYP", DebutDate: "2015-10-20"},
+		{ID: "b2", Name: "WJSN", AgencyName: "Starship", DebutDate: "2016-02-25"},
+	}
+	ps := &kpopnet.Profiles{
+		Bands: bands,
+		Idols: []*kpopnet.Idol{
+			{ID: "i1", BandID: "b1", Name: "Tzuyu", BirthDate: "1999-06-14", ImageID: "x"},
+			{ID: "i2", BandID: "b1", Name: "Chaeyoung", BirthDate: "1999-04-23"},
+			{ID: "i3", BandID: "b2", Name: "Luda", BirthDate: "1997-03-06", ImageID: "y"},
+		},
+		Memberships: []*kpopnet.Membership{
+			{IdolID: "i1", BandID: "b1"},
+			{IdolID: "i2", BandID: "b1"},
+			{IdolID: "i3", BandID: "b2"},
+			{IdolID: "i3", BandID: "b1"},
+		},
+	}
+	bandByID := map[string]*kpopnet.Band{"b1": bands[0], "b2": bands[1]}
+	return ps, bandByID
+}
+
+func idolIDs(page *profilesPage) (ids []string) {
+	for _, v := range page.Idols {
+		ids = append(ids, v.(*kpopnet.Idol).ID)
+	}
+	return
+}
+
+func TestProfileQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"band=b1", []string{"i1", "i2", "i3"}},
+		{"band=b2", []string{"i3"}},
+		{"agency=starship", []string{"i3"}},
+		{"debut_year=2015", []string{"i1", "i2"}},
+		{"has_preview=true", []string{"i1", "i3"}},
+		{"has_preview=0", []string{"i2"}},
+		{"sort=name", []string{"i2", "i3", "i1"}},
+		{"sort=-birth_date", []string{"i1", "i2", "i3"}},
+		{"sort=name&offset=1&limit=1", []string{"i3"}},
+		{"offset=5", nil},
+	}
+	ps, bandByID := testProfiles()
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		q, err := parseProfileQuery(values)
+		if err != nil {
+			t.Errorf("%s: %v", tt.query, err)
+			continue
+		}
+		page, err := q.apply(ps, bandByID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := idolIDs(page)
+		if len(ids) != len(tt.expected) {
+			t.Errorf("%s: expected %v but got %v", tt.query, tt.expected, ids)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v but got %v", tt.query, tt.expected, ids)
+				break
+			}
+		}
+	}
+}
+
+func TestProfileQueryFields(t *testing.T) {
+	ps, bandByID := testProfiles()
+	values, _ := url.ParseQuery("fields=name,image_id&limit=1")
+	q, err := parseProfileQuery(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, err := q.apply(ps, bandByID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.TotalIdols != 3 || len(page.Idols) != 1 {
+		t.Fatalf("bad page: %+v", page)
+	}
+	data, _ := json.Marshal(page.Idols[0])
+	expected := `{"id":"i1","image_id":"x","name":"Tzuyu"}`
+	if string(data) != expected {
+		t.Errorf("expected %s but got %s", expected, data)
+	}
+	if len(page.Memberships) != 1 {
+		t.Errorf("expected memberships of page idols only: %v", page.Memberships)
+	}
+}
+
+func TestProfileQueryErrors(t *testing.T) {
+	for _, query := range []string{"offset=-1", "limit=x", "debut_year=20155", "has_preview=maybe", "sort=height"} {
+		values, _ := url.ParseQuery(query)
+		if _, err := parseProfileQuery(values); err == nil {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/metrics"
+)
+
+// RateLimit configures token bucket of a client.
+type RateLimit struct {
+	// Requests per minute, 0 disables the limit.
+	PerMinute float64
+	// Maximal number of requests in a row, 0 means one minute worth of
+	// requests.
+	Burst int
+}
+
+func (rl RateLimit) burst() float64 {
+	if rl.Burst > 0 {
+		return float64(rl.Burst)
+	}
+	return math.Max(1, math.Floor(rl.PerMinute))
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Token buckets of clients. Limits are passed on every call so they can
+// be changed by Reload.
+type limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newLimiter() *limiter {
+	return &limiter{buckets: make(map[string]*bucket)}
+}
+
+// Take a token from client's bucket. Returns how long to wait if bucket
+// is empty.
+func (l *limiter) take(client string, rl RateLimit, now time.Time) (wait time.Duration) {
+	if rl.PerMinute <= 0 {
+		return
+	}
+	rate := rl.PerMinute / 60
+	burst := rl.burst()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(rate, burst, now)
+	b := l.buckets[client]
+	if b == nil {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return
+}
+
+// Forget buckets which are full already, they are the same as new ones.
+func (l *limiter) sweep(rate, burst float64, now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*rate >= burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+var (
+	keyLimiter = newLimiter()
+	ipLimiter  = newLimiter()
+)
+
+// Charge client for a single recognition. Clients with API key are
+// limited per key, anonymous ones per IP. Returns how long to wait if
+// limit is exceeded.
+func checkRate(r *http.Request) (wait time.Duration) {
+	opts := getOptions()
+	now := time.Now()
+	kind := "ip"
+	if key := requestAPIKey(r.Context()); key != nil {
+		kind = "key"
+		wait = keyLimiter.take(strconv.FormatInt(key.ID, 10), opts.KeyRateLimit, now)
+	} else {
+		wait = ipLimiter.take(clientIP(r), opts.IPRateLimit, now)
+	}
+	if wait > 0 {
+		metrics.RateLimited.WithLabelValues(kind).Inc()
+	}
+	return
+}
+
+// Whole seconds to wait, rounded up.
+func retryAfter(wait time.Duration) int {
+	return int(math.Ceil(wait.Seconds()))
+}
+
+func rateLimitError(wait time.Duration) *kpopnet.Error {
+	return kpopnet.ErrRateLimited.WithDetails(map[string]interface{}{
+		"retry_after": retryAfter(wait),
+	})
+}
+
+// Wrap recognition handler to charge client for the request.
+func limitRate(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if wait := checkRate(r); wait > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter(wait)))
+			serveError(w, r, rateLimitError(wait))
+			return
+		}
+		h(w, r)
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter()
+	rl := RateLimit{PerMinute: 60, Burst: 2}
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if wait := l.take("a", rl, now); wait != 0 {
+			t.Fatalf("request %d: unexpected wait %v", i, wait)
+		}
+	}
+	if wait := l.take("a", rl, now); wait != time.Second {
+		t.Errorf("expected to wait a second but got %v", wait)
+	}
+	if wait := l.take("b", rl, now); wait != 0 {
+		t.Errorf("other client shouldn't be limited but got %v", wait)
+	}
+	if wait := l.take("a", rl, now.Add(time.Second)); wait != 0 {
+		t.Errorf("bucket should be refilled but got %v", wait)
+	}
+	if wait := l.take("a", RateLimit{}, now.Add(time.Second)); wait != 0 {
+		t.Errorf("zero rate should disable limit but got %v", wait)
+	}
+
+	l.take("a", rl, now.Add(time.Hour))
+	if len(l.buckets) != 1 {
+		t.Errorf("expected full buckets to be removed but got %d", len(l.buckets))
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	defer fakeAPIKeys(testKeys)()
+	defer func(opts Options) { options = opts }(options)
+	defer func(l *limiter) { ipLimiter = l }(ipLimiter)
+	defer func(l *limiter) { keyLimiter = l }(keyLimiter)
+	ipLimiter = newLimiter()
+	keyLimiter = newLimiter()
+	options.IPRateLimit = RateLimit{PerMinute: 1}
+	options.KeyRateLimit = RateLimit{PerMinute: 1}
+	router := createRouter()
+
+	// Malformed requests are still charged.
+	post := func(secret string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/recognize", nil)
+		if secret != "" {
+			req.Header.Set("X-API-Key", secret)
+		}
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	if rec := post(""); rec.Code == 429 {
+		t.Fatal("first request shouldn't be limited")
+	}
+	rec := post("")
+	if rec.Code != 429 {
+		t.Fatalf("expected 429 but got %d", rec.Code)
+	}
+	if after := rec.Header().Get("Retry-After"); after != "60" {
+		t.Errorf("unexpected Retry-After %q", after)
+	}
+	// Key has its own bucket.
+	if rec := post("admin"); rec.Code == 429 {
+		t.Error("request with key shouldn't be limited by IP")
+	}
+	if rec := post("admin"); rec.Code != 429 {
+		t.Errorf("expected 429 but got %d", rec.Code)
+	}
+}
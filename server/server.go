@@ -1,23 +1,187 @@
 package server
 
 import (
+	"context"
 	"net/http"
+	"os"
+	"sync"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/cache"
+	"github.com/kpopnet/go-kpopnet/metrics"
 
 	"github.com/dimfeld/httptreemux/v5"
 )
 
-// Start starts HTTP server on specified address.
-func Start(address string) (err error) {
-	router := createRouter()
-	return http.ListenAndServe(address, router)
+// Options of HTTP server.
+type Options struct {
+	// Address to listen on.
+	Address string
+	// Path of Unix socket to listen on instead of Address.
+	Socket string
+	// Permissions of the socket file, 0 keeps default.
+	SocketMode os.FileMode
+	// TLS certificate and key files, empty means plain HTTP. Changed
+	// files are picked up automatically.
+	TLSCert string
+	TLSKey  string
+	// Cache-Control max-age of GET responses in seconds, 0 means clients
+	// should always revalidate.
+	CacheMaxAge int
+	// Path to already uploaded images with {sha1} placeholder, e.g.
+	// /srv/uploads/src/{sha1}.jpg. Empty disables recognition by image ID.
+	ImagePath string
+	// Hosts to fetch images from. Host starting with dot allows all its
+	// subdomains. Empty disables recognition by URL.
+	FetchHosts []string
+	// Fetch timeout in seconds.
+	FetchTimeout int
+	// How long to keep finished jobs in seconds, 0 means default of one
+	// hour.
+	JobRetention int
+	// Hosts to send job callbacks to, same format as FetchHosts. Empty
+	// disables callbacks.
+	CallbackHosts []string
+	// Enable admin API. It's available only to API keys with admin
+	// scope.
+	EnableAdmin bool
+	// Audit and clustering of faces are quadratic, so admin API refuses
+	// to run them over more faces, CLI commands should be used instead.
+	// 0 means default.
+	MaxAdminFaces int
+	// Reject requests without API key. Otherwise anonymous clients can
+	// read profiles and recognize faces.
+	RequireAPIKey bool
+	// Recognition limits of every API key and of every anonymous client
+	// IP.
+	KeyRateLimit RateLimit
+	IPRateLimit  RateLimit
+	// Cross-origin access to API.
+	CORS CORSOptions
+	// Header with client IP set by reverse proxy. Single-value header
+	// like X-Real-IP overwritten by proxy is safe. For X-Forwarded-For
+	// the rightmost address is used, so it's only correct behind exactly
+	// one proxy which appends to it. Empty means remote address of the
+	// connection is used.
+	IPHeader string
+}
+
+var (
+	optionsMu sync.RWMutex
+	options   Options
+
+	// Shutdown can be called from another goroutine before Start gets
+	// to serving.
+	serverMu   sync.Mutex
+	httpServer *http.Server
+	stopping   bool
+)
+
+// Get current options, they can be changed by Reload.
+func getOptions() Options {
+	optionsMu.RLock()
+	defer optionsMu.RUnlock()
+	return options
+}
+
+// Start starts HTTP server with specified options. It returns nil after
+// Shutdown.
+func Start(opts Options) (err error) {
+	if err = opts.CORS.validate(); err != nil {
+		return
+	}
+	optionsMu.Lock()
+	options = opts
+	optionsMu.Unlock()
+	setupJobs()
+	ln, err := listen(opts)
+	if err != nil {
+		return
+	}
+	serverMu.Lock()
+	if stopping {
+		serverMu.Unlock()
+		return ln.Close()
+	}
+	srv := &http.Server{Handler: createRouter()}
+	httpServer = srv
+	serverMu.Unlock()
+	err = srv.Serve(ln)
+	if err == http.ErrServerClosed {
+		err = nil
+	}
+	return
+}
+
+// Shutdown gracefully stops the server, waiting for active requests and
+// pending jobs to finish.
+func Shutdown(ctx context.Context) (err error) {
+	serverMu.Lock()
+	stopping = true
+	srv := httpServer
+	serverMu.Unlock()
+	stopJobsCleanup()
+	if srv != nil {
+		if err = srv.Shutdown(ctx); err != nil {
+			return
+		}
+	}
+	return waitJobs(ctx)
+}
+
+// Reload applies new options and reloads cached profiles. Listener
+// options can't be changed without restart.
+func Reload(ctx context.Context, opts Options) (err error) {
+	if err = opts.CORS.validate(); err != nil {
+		return
+	}
+	optionsMu.Lock()
+	opts.Address = options.Address
+	opts.Socket = options.Socket
+	opts.SocketMode = options.SocketMode
+	opts.TLSCert = options.TLSCert
+	opts.TLSKey = options.TLSKey
+	options = opts
+	optionsMu.Unlock()
+	cache.ClearProfilesCache()
+	_, err = getProfiles(ctx)
+	return
 }
 
 func createRouter() http.Handler {
+	return logRequests(createMux())
+}
+
+// Register all routes.
+func createMux() *httptreemux.TreeMux {
+	const (
+		read      = kpopnet.ScopeRead
+		recognize = kpopnet.ScopeRecognize
+		admin     = kpopnet.ScopeAdmin
+	)
 	r := httptreemux.New()
+	r.UsingContext().Handler("GET", "/metrics", metrics.Handler())
+	r.UsingContext().GET("/healthz", ServeHealth)
+	r.UsingContext().GET("/readyz", ServeReady)
 
-	api := r.UsingContext().NewGroup("/api")
-	api.GET("/profiles", ServeProfiles)
-	api.POST("/recognize", ServeRecognize)
+	group := r.UsingContext().NewGroup("/api")
+	group.UseHandler(cors)
+	api := newInstrumentedGroup(group)
+	api.GET("/openapi.json", ServeOpenAPI)
+	api.GET("/profiles", authorize(read, ServeProfiles))
+	api.GET("/idols/:id", authorize(read, ServeIdol))
+	api.GET("/idols/:id/similar", authorize(read, ServeSimilarIdols))
+	api.GET("/bands/:id", authorize(read, ServeBand))
+	api.GET("/bands/:id/idols", authorize(read, ServeBandIdols))
+	api.GET("/search", authorize(read, ServeSearch))
+	api.POST("/recognize", authorize(recognize, limitRate(ServeRecognize)))
+	api.POST("/recognize/batch", authorize(recognize, ServeRecognizeBatch))
+	api.POST("/verify", authorize(recognize, limitRate(ServeVerify)))
+	api.POST("/jobs", authorize(recognize, limitRate(ServeCreateJob)))
+	api.GET("/jobs/:id", authorize(read, ServeJob))
+	api.GET("/admin/faces/audit", adminOnly(authorize(admin, ServeAuditFaces)))
+	api.GET("/admin/faces/clusters", adminOnly(authorize(admin, ServeClusterFaces)))
+	api.POST("/admin/faces/label", adminOnly(authorize(admin, ServeLabelFaces)))
 
-	return http.Handler(r)
+	return r
 }
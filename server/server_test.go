@@ -0,0 +1,25 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestShutdownBeforeServe(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	defer func() { stopping, httpServer = false, nil }()
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error)
+	go func() { done <- Start(Options{Address: "127.0.0.1:0"}) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server started after shutdown")
+	}
+}
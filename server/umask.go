@@ -0,0 +1,15 @@
+// +build !windows
+
+package server
+
+import (
+	"syscall"
+)
+
+// Run f with the given umask. Umask is process-wide so it should be only
+// used on start.
+func withUmask(mask int, f func() error) error {
+	old := syscall.Umask(mask)
+	defer syscall.Umask(old)
+	return f()
+}
@@ -0,0 +1,6 @@
+package server
+
+// There is no umask on Windows.
+func withUmask(mask int, f func() error) error {
+	return f()
+}
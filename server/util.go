@@ -1,20 +1,34 @@
 package server
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
-	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/logging"
+
+	"github.com/dimfeld/httptreemux/v5"
 )
 
-func logError(err error) {
-	log.Printf("kpopnet: %s\n%s\n", err, debug.Stack())
+// Map error of reading request body limited by http.MaxBytesReader,
+// other errors are replaced by fallback.
+func bodyError(err error, fallback *kpopnet.Error) error {
+	// Only error message is available before Go 1.19.
+	if strings.Contains(err.Error(), "http: request body too large") {
+		return kpopnet.ErrTooLarge
+	}
+	return fallback
+}
+
+func getParam(r *http.Request, name string) string {
+	return httptreemux.ContextParams(r.Context())[name]
 }
 
 func hashBytes(buf []byte) string {
@@ -22,25 +36,61 @@ func hashBytes(buf []byte) string {
 	return base64.RawStdEncoding.EncodeToString(hash[:])
 }
 
-func checkEtag(w http.ResponseWriter, r *http.Request, etag string) bool {
-	if r.Header.Get("If-None-Match") == etag {
-		w.WriteHeader(304)
-		return true
+func makeEtag(data []byte) string {
+	return fmt.Sprintf("\"%s\"", hashBytes(data))
+}
+
+// Weak comparison is used for If-None-Match, see RFC 7232, 3.2.
+func matchEtag(header string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
 	}
 	return false
 }
 
+// Check whether client already has the actual version of the resource.
+// If-Modified-Since is ignored if If-None-Match is present.
+func checkFresh(r *http.Request, etag string, modTime time.Time) bool {
+	if tags := r.Header.Values("If-None-Match"); len(tags) > 0 {
+		return matchEtag(strings.Join(tags, ","), etag)
+	}
+	if modTime.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modTime.Truncate(time.Second).After(since)
+}
+
 func setAPIHeaders(w http.ResponseWriter) {
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 }
 
-func serveEncodedJSON(w http.ResponseWriter, r *http.Request, data []byte) {
+func getCacheControl() string {
+	if maxAge := getOptions().CacheMaxAge; maxAge > 0 {
+		return fmt.Sprintf("max-age=%d", maxAge)
+	}
+	return "no-cache"
+}
+
+// Serve already encoded JSON with validators. Zero modTime means
+// modification time is unknown.
+func serveEncodedJSON(w http.ResponseWriter, r *http.Request, data []byte, etag string, modTime time.Time) {
 	setAPIHeaders(w)
-	etag := fmt.Sprintf("W/\"%s\"", hashBytes(data))
+	w.Header().Set("Cache-Control", getCacheControl())
 	w.Header().Set("ETag", etag)
-	if checkEtag(w, r, etag) {
+	if !modTime.IsZero() {
+		w.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	}
+	if checkFresh(r, etag, modTime) {
+		w.WriteHeader(304)
 		return
 	}
 	w.Write(data)
@@ -49,23 +99,33 @@ func serveEncodedJSON(w http.ResponseWriter, r *http.Request, data []byte) {
 func serveJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
 	data, err := json.Marshal(v)
 	if err != nil {
-		serve500(w, r, err)
+		serveError(w, r, err)
 		return
 	}
-	serveEncodedJSON(w, r, data)
+	serveEncodedJSON(w, r, data, makeEtag(data), time.Time{})
 }
 
-func serveError(w http.ResponseWriter, r *http.Request, err error, code int) {
-	setAPIHeaders(w)
-	w.WriteHeader(code)
-	io.WriteString(w, fmt.Sprintf("{\"error\": \"%v\"}", err))
-}
-
-func serve400(w http.ResponseWriter, r *http.Request, err error) {
-	serveError(w, r, err, 400)
+// Convert arbitrary error to API error. All non-API errors are logged
+// and reported as internal.
+func toAPIError(ctx context.Context, err error) *kpopnet.Error {
+	var apiErr *kpopnet.Error
+	if !errors.As(err, &apiErr) {
+		logging.Error(ctx, "internal error", "error", err)
+		apiErr = kpopnet.ErrInternal
+	}
+	return apiErr
 }
 
-func serve500(w http.ResponseWriter, r *http.Request, err error) {
-	logError(err)
-	serveError(w, r, kpopnet.ErrInternal, 500)
+// Serve API error with its status code.
+func serveError(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := toAPIError(r.Context(), err)
+	data, err := json.Marshal(apiErr)
+	if err != nil {
+		logging.Error(r.Context(), "error encoding error", "error", err)
+		apiErr = kpopnet.ErrInternal
+		data, _ = json.Marshal(apiErr)
+	}
+	setAPIHeaders(w)
+	w.WriteHeader(apiErr.Status)
+	w.Write(data)
 }
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckFresh(t *testing.T) {
+	etag := `"abc"`
+	modTime := time.Date(2020, 5, 1, 12, 0, 0, 500, time.UTC)
+	tests := []struct {
+		header   string
+		value    string
+		expected bool
+	}{
+		{"If-None-Match", `"abc"`, true},
+		{"If-None-Match", `W/"abc"`, true},
+		{"If-None-Match", `"xyz", "abc"`, true},
+		{"If-None-Match", `*`, true},
+		{"If-None-Match", `"xyz"`, false},
+		{"If-Modified-Since", "Fri, 01 May 2020 12:00:00 GMT", true},
+		{"If-Modified-Since", "Fri, 01 May 2020 13:00:00 GMT", true},
+		{"If-Modified-Since", "Fri, 01 May 2020 11:59:59 GMT", false},
+		{"If-Modified-Since", "garbage", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/api/profiles", nil)
+		r.Header.Set(tt.header, tt.value)
+		if actual := checkFresh(r, etag, modTime); actual != tt.expected {
+			t.Errorf("%s: %s: expected %v", tt.header, tt.value, tt.expected)
+		}
+	}
+
+	// If-None-Match takes precedence.
+	r := httptest.NewRequest("GET", "/api/profiles", nil)
+	r.Header.Set("If-None-Match", `"xyz"`)
+	r.Header.Set("If-Modified-Since", "Fri, 01 May 2020 13:00:00 GMT")
+	if checkFresh(r, etag, modTime) {
+		t.Error("If-Modified-Since shouldn't be used with If-None-Match")
+	}
+}
+
+func TestServeEncodedJSON(t *testing.T) {
+	defer func(opts Options) { options = opts }(options)
+	options.CacheMaxAge = 60
+	modTime := time.Now()
+	r := httptest.NewRequest("GET", "/api/profiles", nil)
+	w := httptest.NewRecorder()
+	serveEncodedJSON(w, r, []byte("{}"), `"abc"`, modTime)
+	h := w.Result().Header
+	if h.Get("Cache-Control") != "max-age=60" {
+		t.Errorf("bad Cache-Control: %s", h.Get("Cache-Control"))
+	}
+	if h.Get("Last-Modified") == "" {
+		t.Error("missing Last-Modified")
+	}
+
+	r.Header.Set("If-Modified-Since", h.Get("Last-Modified"))
+	w = httptest.NewRecorder()
+	serveEncodedJSON(w, r, []byte("{}"), `"abc"`, modTime)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected 304 but got %d", w.Code)
+	}
+}
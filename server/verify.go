@@ -0,0 +1,103 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/kpopnet/go-kpopnet"
+	"github.com/kpopnet/go-kpopnet/facerec"
+)
+
+// Request to verify either two images or image and idol.
+type verifyRequest struct {
+	Images []recognizeRequest `json:"images"`
+	IdolID string             `json:"idol_id"`
+}
+
+// Check that request has either two images or one image and idol ID.
+func checkVerifyImages(n int, idolID string) bool {
+	if idolID != "" {
+		return n == 1
+	}
+	return n == 2
+}
+
+// ServeVerify checks whether two images show the same person or whether
+// image shows the given idol. Images are uploaded via HTTP as files[]
+// with optional idol_id field or referenced by JSON request.
+func ServeVerify(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxBodySize)
+	var images [][]byte
+	var idolID string
+	var err error
+	ctype, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if ctype == "application/json" {
+		images, idolID, err = readVerifyJSON(r)
+	} else {
+		images, idolID, err = readVerifyForm(r)
+	}
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	var v *facerec.Verification
+	if idolID != "" {
+		v, err = facerec.RequestVerifyIdol(r.Context(), images[0], idolID)
+	} else {
+		v, err = facerec.RequestVerify(r.Context(), images[0], images[1])
+	}
+	if err != nil {
+		serveError(w, r, err)
+		return
+	}
+	serveJSON(w, r, v)
+}
+
+func readVerifyJSON(r *http.Request) (images [][]byte, idolID string, err error) {
+	var req verifyRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		err = kpopnet.ErrParseJSON
+		return
+	}
+	if !checkVerifyImages(len(req.Images), req.IdolID) {
+		err = kpopnet.ErrParseJSON
+		return
+	}
+	for _, img := range req.Images {
+		var imgData []byte
+		if imgData, err = img.load(r.Context()); err != nil {
+			return
+		}
+		images = append(images, imgData)
+	}
+	idolID = req.IdolID
+	return
+}
+
+func readVerifyForm(r *http.Request) (images [][]byte, idolID string, err error) {
+	if err = r.ParseMultipartForm(0); err != nil {
+		err = kpopnet.ErrParseForm
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	idolID = r.FormValue("idol_id")
+	fhs := r.MultipartForm.File["files[]"]
+	if !checkVerifyImages(len(fhs), idolID) {
+		err = kpopnet.ErrParseFile
+		return
+	}
+	for _, fh := range fhs {
+		fh := fh
+		var imgData []byte
+		imgData, err = readBatchFile(batchFile{fh.Filename, func() (io.ReadCloser, error) {
+			return fh.Open()
+		}})
+		if err != nil {
+			return
+		}
+		images = append(images, imgData)
+	}
+	return
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServeVerifyBadRequest(t *testing.T) {
+	tests := []string{
+		`{"images": [{"image_id": "a"}]}`,
+		`{"images": [{"image_id": "a"}, {"image_id": "b"}], "idol_id": "c"}`,
+		`{"images": [{"image_id": "a"}, {"image_id": "b"}, {"image_id": "c"}]}`,
+		`{"idol_id": "c"}`,
+		`not json`,
+	}
+	for _, body := range tests {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/api/verify", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		createRouter().ServeHTTP(rec, req)
+		if rec.Code != 400 || !strings.Contains(rec.Body.String(), `"parse_json"`) {
+			t.Errorf("%s: unexpected response %d %s", body, rec.Code, rec.Body)
+		}
+	}
+}